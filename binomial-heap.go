@@ -0,0 +1,258 @@
+package datastructures
+
+import (
+	"errors"
+	"fmt"
+)
+
+// binomialHeapHandle is the HeapHandle of an item of a binomial heap.
+//
+// items move between nodes when they are decreased, so the handle points
+// to the node that holds the item and the node points back to the handle.
+type binomialHeapHandle[T any] struct {
+	item T
+	node *binomialHeapNode[T]
+	// owner is nil once the item is removed.
+	owner *heapOwner
+}
+
+// Item returns the item of the handle.
+func (handle *binomialHeapHandle[T]) Item() T {
+	return handle.item
+}
+
+// binomialHeapNode is a node of a binomial tree.
+type binomialHeapNode[T any] struct {
+	handle *binomialHeapHandle[T]
+	parent *binomialHeapNode[T]
+	// child is the child with the largest degree.
+	child *binomialHeapNode[T]
+	// sibling is the next root or the next child of the parent, which has
+	// a smaller degree.
+	sibling *binomialHeapNode[T]
+	degree  int
+}
+
+// BinomialHeap represents a binomial heap data structure, a list of
+// binomial trees with distinct degrees.
+//
+// all operations are O(log n).
+type BinomialHeap[T any] struct {
+	// head is the root with the smallest degree, the roots are sorted by
+	// degree.
+	head  *binomialHeapNode[T]
+	size  int
+	less  func(a, b T) bool
+	owner *heapOwner
+}
+
+// NewBinomialHeap returns a new binomial heap data structure ordered by
+// less.
+func NewBinomialHeap[T any](less func(a, b T) bool) *BinomialHeap[T] {
+	return &BinomialHeap[T]{less: less, owner: &heapOwner{}}
+}
+
+// Insert adds a new item to the heap and returns its handle.
+func (h *BinomialHeap[T]) Insert(item T) HeapHandle[T] {
+	handle := &binomialHeapHandle[T]{item: item, owner: h.owner}
+	handle.node = &binomialHeapNode[T]{handle: handle}
+	h.head = h.union(h.head, handle.node)
+	h.size++
+	return handle
+}
+
+// Push adds a new item to the heap, it is the same as Insert.
+func (h *BinomialHeap[T]) Push(item T) HeapHandle[T] {
+	return h.Insert(item)
+}
+
+// Min returns the smallest item of the heap without removing it.
+func (h *BinomialHeap[T]) Min() (T, error) {
+	if h.head == nil {
+		var zero T
+		return zero, errors.New("heap is empty")
+	}
+	_, root := h.minRoot()
+	return root.handle.item, nil
+}
+
+// ExtractMin removes the smallest item from the heap.
+func (h *BinomialHeap[T]) ExtractMin() (T, error) {
+	if h.head == nil {
+		var zero T
+		return zero, errors.New("heap is empty")
+	}
+	previous, root := h.minRoot()
+	h.removeRoot(previous, root)
+	return root.handle.item, nil
+}
+
+// DecreaseKey replaces the item of handle with item, which must not be
+// greater than the current item.
+//
+// the item is moved up by swapping it with the items of its ancestors.
+func (h *BinomialHeap[T]) DecreaseKey(handle HeapHandle[T], item T) error {
+	element, err := h.handle(handle)
+	if err != nil {
+		return err
+	}
+	if h.less(element.item, item) {
+		return fmt.Errorf("%w: %v is greater than %v", ErrInvalidDecrease, item, element.item)
+	}
+	element.item = item
+	h.siftUp(element.node, false)
+	return nil
+}
+
+// Delete removes the item of handle from the heap.
+//
+// the item is moved up to the root of its tree and the root is removed.
+func (h *BinomialHeap[T]) Delete(handle HeapHandle[T]) error {
+	element, err := h.handle(handle)
+	if err != nil {
+		return err
+	}
+	root := h.siftUp(element.node, true)
+	var previous *binomialHeapNode[T]
+	for node := h.head; node != root; node = node.sibling {
+		previous = node
+	}
+	h.removeRoot(previous, root)
+	return nil
+}
+
+// Meld moves the items of other, which must be a *BinomialHeap[T], to the
+// heap, other is emptied.
+func (h *BinomialHeap[T]) Meld(other PriorityQueue[T]) error {
+	o, ok := other.(*BinomialHeap[T])
+	if !ok {
+		return fmt.Errorf("%w: %T and %T", ErrIncompatibleHeap, h, other)
+	}
+	if o == h {
+		return nil
+	}
+	h.head = h.union(h.head, o.head)
+	h.size += o.size
+	o.owner.melded = h.owner
+	*o = *NewBinomialHeap(o.less)
+	return nil
+}
+
+// Size returns the number of items in the heap.
+func (h *BinomialHeap[T]) Size() int {
+	return h.size
+}
+
+// handle returns handle as a handle of the heap.
+func (h *BinomialHeap[T]) handle(handle HeapHandle[T]) (*binomialHeapHandle[T], error) {
+	element, ok := handle.(*binomialHeapHandle[T])
+	if !ok || element.owner == nil || element.owner.find() != h.owner {
+		return nil, ErrInvalidHandle
+	}
+	return element, nil
+}
+
+// minRoot returns the root with the smallest item and the root before it.
+func (h *BinomialHeap[T]) minRoot() (*binomialHeapNode[T], *binomialHeapNode[T]) {
+	var previous, minPrevious *binomialHeapNode[T]
+	min := h.head
+	for node := h.head; node != nil; previous, node = node, node.sibling {
+		if h.less(node.handle.item, min.handle.item) {
+			min, minPrevious = node, previous
+		}
+	}
+	return minPrevious, min
+}
+
+// removeRoot removes root from the roots, its children become a heap that
+// is melded with the remaining roots.
+func (h *BinomialHeap[T]) removeRoot(previous, root *binomialHeapNode[T]) {
+	if previous == nil {
+		h.head = root.sibling
+	} else {
+		previous.sibling = root.sibling
+	}
+	// the children are sorted by decreasing degree, they are reversed to
+	// become a list of roots.
+	var children *binomialHeapNode[T]
+	for child := root.child; child != nil; {
+		next := child.sibling
+		child.parent, child.sibling = nil, children
+		children = child
+		child = next
+	}
+	h.head = h.union(h.head, children)
+	h.size--
+	root.handle.owner, root.handle.node = nil, nil
+}
+
+// siftUp swaps the item of node with the item of its parent until the
+// parent item is not greater, or until the root if force is true. it
+// returns the node that holds the item.
+func (h *BinomialHeap[T]) siftUp(node *binomialHeapNode[T], force bool) *binomialHeapNode[T] {
+	for node.parent != nil && (force || h.less(node.handle.item, node.parent.handle.item)) {
+		parent := node.parent
+		node.handle, parent.handle = parent.handle, node.handle
+		node.handle.node, parent.handle.node = node, parent
+		node = parent
+	}
+	return node
+}
+
+// union merges two lists of roots sorted by degree and links the trees
+// with the same degree, it returns the head of the new list.
+func (h *BinomialHeap[T]) union(a, b *binomialHeapNode[T]) *binomialHeapNode[T] {
+	head := binomialHeapMergeRoots(a, b)
+	if head == nil {
+		return nil
+	}
+	var previous *binomialHeapNode[T]
+	node, next := head, head.sibling
+	for next != nil {
+		if node.degree != next.degree || (next.sibling != nil && next.sibling.degree == node.degree) {
+			previous, node = node, next
+		} else if !h.less(next.handle.item, node.handle.item) {
+			node.sibling = next.sibling
+			binomialHeapLink(next, node)
+		} else {
+			if previous == nil {
+				head = next
+			} else {
+				previous.sibling = next
+			}
+			binomialHeapLink(node, next)
+			node = next
+		}
+		next = node.sibling
+	}
+	return head
+}
+
+// binomialHeapMergeRoots merges two lists of roots sorted by degree.
+func binomialHeapMergeRoots[T any](a, b *binomialHeapNode[T]) *binomialHeapNode[T] {
+	var head binomialHeapNode[T]
+	tail := &head
+	for a != nil && b != nil {
+		if a.degree <= b.degree {
+			tail.sibling, a = a, a.sibling
+		} else {
+			tail.sibling, b = b, b.sibling
+		}
+		tail = tail.sibling
+	}
+	if a != nil {
+		tail.sibling = a
+	} else {
+		tail.sibling = b
+	}
+	return head.sibling
+}
+
+// binomialHeapLink makes child the first child of parent, both trees have
+// the same degree.
+func binomialHeapLink[T any](child, parent *binomialHeapNode[T]) {
+	child.parent = parent
+	child.sibling = parent.child
+	parent.child = child
+	parent.degree++
+}
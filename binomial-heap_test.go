@@ -0,0 +1,83 @@
+package datastructures
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// validateBinomialHeap is a test helper that checks that the roots have
+// increasing degrees, that a tree of degree k has 2^k nodes in heap order
+// and that the handles point to their nodes. it returns the number of
+// nodes.
+func validateBinomialHeap[T any](t *testing.T, h *BinomialHeap[T]) int {
+	t.Helper()
+	var walk func(node *binomialHeapNode[T]) int
+	walk = func(node *binomialHeapNode[T]) int {
+		if node.handle.node != node {
+			t.Fatalf("BinomialHeap handle of %v points to another node", node.handle.item)
+		}
+		count, degree := 1, node.degree
+		for child := node.child; child != nil; child = child.sibling {
+			degree--
+			if child.parent != node || child.degree != degree {
+				t.Fatalf("BinomialHeap child %v has a wrong parent or degree", child.handle.item)
+			}
+			if h.less(child.handle.item, node.handle.item) {
+				t.Fatalf("BinomialHeap node %v is less than its parent %v", child.handle.item, node.handle.item)
+			}
+			count += walk(child)
+		}
+		if degree != 0 || count != 1<<node.degree {
+			t.Fatalf("BinomialHeap tree of degree %v has %v nodes", node.degree, count)
+		}
+		return count
+	}
+	count, previousDegree := 0, -1
+	for root := h.head; root != nil; root = root.sibling {
+		if root.degree <= previousDegree || root.parent != nil {
+			t.Fatalf("BinomialHeap roots are not sorted by distinct degrees")
+		}
+		previousDegree = root.degree
+		count += walk(root)
+	}
+	return count
+}
+
+func TestBinomialHeap_structure(t *testing.T) {
+	tests := []struct {
+		name      string
+		items     int
+		decreases int
+		deletes   int
+		extracts  int
+	}{
+		{name: "inserts", items: 100},
+		{name: "inserts and extracts", items: 100, extracts: 60},
+		{name: "decreases and deletes", items: 200, decreases: 150, deletes: 50, extracts: 30},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			random := rand.New(rand.NewSource(5))
+			h := NewBinomialHeap(func(a, b int) bool { return a < b })
+			handles := []HeapHandle[int]{}
+			for i := 0; i < tt.items; i++ {
+				handles = append(handles, h.Insert(random.Intn(1000)))
+			}
+			for i := 0; i < tt.decreases; i++ {
+				handle := handles[random.Intn(len(handles))]
+				h.DecreaseKey(handle, handle.Item()-random.Intn(500))
+			}
+			random.Shuffle(len(handles), func(i, j int) { handles[i], handles[j] = handles[j], handles[i] })
+			for _, handle := range handles[:tt.deletes] {
+				h.Delete(handle)
+			}
+			for i := 0; i < tt.extracts; i++ {
+				h.ExtractMin()
+			}
+			want := tt.items - tt.deletes - tt.extracts
+			if got := validateBinomialHeap(t, h); got != want || h.Size() != want {
+				t.Errorf("BinomialHeap has %v nodes and Size() = %v, want %v", got, h.Size(), want)
+			}
+		})
+	}
+}
@@ -0,0 +1,354 @@
+package datastructures
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+var (
+	// ErrIncompatibleFilter is returned when filters with different sizes,
+	// numbers of hash functions or seeds are combined.
+	ErrIncompatibleFilter = errors.New("incompatible filter")
+	// ErrInvalidFilterEncoding is returned when a filter cannot be decoded.
+	ErrInvalidFilterEncoding = errors.New("invalid filter encoding")
+)
+
+const (
+	bloomFilterEncodingVersion = 1
+	// bloomFilterHeaderSize is the size of the encoding header: a kind
+	// byte, a version byte, the number of bits, the number of hash
+	// functions and the seed.
+	bloomFilterHeaderSize = 2 + 8 + 4 + 8
+
+	// bloomFilterMaxHashFunctions bounds the number of hash functions of
+	// a decoded filter, a false positive rate of 1e-30 only needs 100.
+	bloomFilterMaxHashFunctions = 1024
+
+	bloomFilterKind         byte = 'B'
+	countingBloomFilterKind byte = 'C'
+)
+
+// bloomFilterHashes holds the settings shared by the bloom filters to
+// find the positions of a key.
+type bloomFilterHashes struct {
+	// m is the number of positions (bits or counters) of the filter.
+	m uint64
+	// k is the number of hash functions, the number of positions of a key.
+	k      int
+	hasher Hasher
+	seed   uint64
+}
+
+// newBloomFilterHashes returns the settings of a filter sized for
+// expectedItems items with a falsePositiveRate false positive rate.
+func newBloomFilterHashes(expectedItems int, falsePositiveRate float64, options []HashTableOption) bloomFilterHashes {
+	if expectedItems < 1 {
+		expectedItems = 1
+	}
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		falsePositiveRate = 0.01
+	}
+	n := float64(expectedItems)
+	m := math.Ceil(-n * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	k := int(math.Round(m / n * math.Ln2))
+	if k < 1 {
+		k = 1
+	}
+	// the seed is 0 unless a seed option is provided, so that filters
+	// created in different processes can be combined.
+	config := newHashTableConfig(0, 0, append([]HashTableOption{HashTableSeed(0)}, options...))
+	return bloomFilterHashes{m: uint64(m), k: k, hasher: config.hasher, seed: config.seed}
+}
+
+// positions calls f with the k positions of key.
+//
+// the positions are built from two hashes of the key (Kirsch-Mitzenmacher
+// double hashing), so the key is only hashed once.
+func (h bloomFilterHashes) positions(key interface{}, f func(position uint64)) error {
+	hasher := h.hasher
+	if hasher == nil {
+		hasher = DefaultHasher
+	}
+	first, err := hasher.Hash(key, h.seed)
+	if err != nil {
+		return err
+	}
+	second := mixHash(first) | 1
+	for i := 0; i < h.k; i++ {
+		f((first + uint64(i)*second) % h.m)
+	}
+	return nil
+}
+
+// compatible returns an error if filters with the settings h and other
+// cannot be combined.
+func (h bloomFilterHashes) compatible(other bloomFilterHashes) error {
+	if h.m != other.m || h.k != other.k || h.seed != other.seed {
+		return fmt.Errorf(
+			"%w: (%v positions, %v hashes, seed %v) and (%v positions, %v hashes, seed %v)",
+			ErrIncompatibleFilter, h.m, h.k, h.seed, other.m, other.k, other.seed,
+		)
+	}
+	return nil
+}
+
+// marshalHeader returns the encoding header of a filter of kind.
+func (h bloomFilterHashes) marshalHeader(kind byte, dataSize int) []byte {
+	data := make([]byte, bloomFilterHeaderSize, bloomFilterHeaderSize+dataSize)
+	data[0] = kind
+	data[1] = bloomFilterEncodingVersion
+	binary.BigEndian.PutUint64(data[2:], h.m)
+	binary.BigEndian.PutUint32(data[10:], uint32(h.k))
+	binary.BigEndian.PutUint64(data[14:], h.seed)
+	return data
+}
+
+// unmarshalHeader decodes the encoding header of a filter of kind and
+// returns the data that follows it.
+func (h *bloomFilterHashes) unmarshalHeader(kind byte, data []byte) ([]byte, error) {
+	if len(data) < bloomFilterHeaderSize || data[0] != kind || data[1] != bloomFilterEncodingVersion {
+		return nil, fmt.Errorf("%w: bad header", ErrInvalidFilterEncoding)
+	}
+	m := binary.BigEndian.Uint64(data[2:])
+	k := binary.BigEndian.Uint32(data[10:])
+	if m == 0 || m > math.MaxInt32*64 || k == 0 || k > bloomFilterMaxHashFunctions {
+		return nil, fmt.Errorf("%w: %v positions and %v hash functions", ErrInvalidFilterEncoding, m, k)
+	}
+	h.m = m
+	h.k = int(k)
+	h.seed = binary.BigEndian.Uint64(data[14:])
+	return data[bloomFilterHeaderSize:], nil
+}
+
+// BloomFilter represents a bloom filter data structure, a probabilistic
+// set that tells if a key may have been added.
+//
+// MayContain never returns false for a key that was added, but it can
+// return true for a key that was not added (false positive).
+type BloomFilter struct {
+	hashes bloomFilterHashes
+	bits   []uint64
+}
+
+// NewBloomFilter returns a new bloom filter sized so that the false positive
+// rate stays below falsePositiveRate until expectedItems items are added.
+//
+// the hasher and seed options of the hash tables can be used, the seed is 0
+// by default so that filters of the same size can be combined.
+func NewBloomFilter(expectedItems int, falsePositiveRate float64, options ...HashTableOption) *BloomFilter {
+	hashes := newBloomFilterHashes(expectedItems, falsePositiveRate, options)
+	return &BloomFilter{
+		hashes: hashes,
+		bits:   make([]uint64, (hashes.m+63)/64),
+	}
+}
+
+// Add adds key to the bloom filter.
+func (b *BloomFilter) Add(key interface{}) error {
+	return b.hashes.positions(key, func(position uint64) {
+		b.bits[position/64] |= 1 << (position % 64)
+	})
+}
+
+// MayContain returns true if key may have been added to the bloom filter,
+// and false if it was definitely not added.
+func (b *BloomFilter) MayContain(key interface{}) bool {
+	found := true
+	err := b.hashes.positions(key, func(position uint64) {
+		if b.bits[position/64]&(1<<(position%64)) == 0 {
+			found = false
+		}
+	})
+	return err == nil && found
+}
+
+// Union adds the keys of other to the bloom filter.
+//
+// both filters must have the same size, number of hash functions and seed.
+func (b *BloomFilter) Union(other *BloomFilter) error {
+	if err := b.hashes.compatible(other.hashes); err != nil {
+		return err
+	}
+	for i := range b.bits {
+		b.bits[i] |= other.bits[i]
+	}
+	return nil
+}
+
+// FillRatio returns the ratio of bits that are set, the false positive
+// rate of the bloom filter is about FillRatio()^HashFunctions().
+func (b *BloomFilter) FillRatio() float64 {
+	set := 0
+	for _, word := range b.bits {
+		set += bits.OnesCount64(word)
+	}
+	return float64(set) / float64(b.hashes.m)
+}
+
+// Bits returns the number of bits of the bloom filter.
+func (b *BloomFilter) Bits() int {
+	return int(b.hashes.m)
+}
+
+// HashFunctions returns the number of bits set for every key.
+func (b *BloomFilter) HashFunctions() int {
+	return b.hashes.k
+}
+
+// MarshalBinary encodes the bloom filter, the seed is part of the encoding.
+func (b *BloomFilter) MarshalBinary() ([]byte, error) {
+	data := b.hashes.marshalHeader(bloomFilterKind, len(b.bits)*8)
+	for _, word := range b.bits {
+		data = binary.BigEndian.AppendUint64(data, word)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary decodes a bloom filter encoded with MarshalBinary.
+//
+// the hasher of the bloom filter is kept, DefaultHasher is used if it has
+// none.
+func (b *BloomFilter) UnmarshalBinary(data []byte) error {
+	hashes := b.hashes
+	data, err := hashes.unmarshalHeader(bloomFilterKind, data)
+	if err != nil {
+		return err
+	}
+	if uint64(len(data)) != (hashes.m+63)/64*8 {
+		return fmt.Errorf("%w: %v bytes of bits, want %v", ErrInvalidFilterEncoding, len(data), (hashes.m+63)/64*8)
+	}
+	words := make([]uint64, len(data)/8)
+	for i := range words {
+		words[i] = binary.BigEndian.Uint64(data[i*8:])
+	}
+	b.hashes = hashes
+	b.bits = words
+	return nil
+}
+
+// CountingBloomFilter represents a bloom filter data structure that keeps
+// a counter for every position instead of a bit, so that keys can be
+// removed.
+//
+// the counters saturate at 255, a saturated counter is never decremented.
+type CountingBloomFilter struct {
+	hashes   bloomFilterHashes
+	counters []uint8
+}
+
+// NewCountingBloomFilter returns a new counting bloom filter sized so that
+// the false positive rate stays below falsePositiveRate until expectedItems
+// items are added.
+//
+// the seed is 0 by default like the seed of a BloomFilter.
+func NewCountingBloomFilter(expectedItems int, falsePositiveRate float64, options ...HashTableOption) *CountingBloomFilter {
+	hashes := newBloomFilterHashes(expectedItems, falsePositiveRate, options)
+	return &CountingBloomFilter{
+		hashes:   hashes,
+		counters: make([]uint8, hashes.m),
+	}
+}
+
+// Add adds key to the counting bloom filter.
+func (c *CountingBloomFilter) Add(key interface{}) error {
+	return c.hashes.positions(key, func(position uint64) {
+		if c.counters[position] < math.MaxUint8 {
+			c.counters[position]++
+		}
+	})
+}
+
+// Remove removes key from the counting bloom filter.
+//
+// it returns false if the key is definitely not in the filter. removing a
+// key that was not added can remove other keys.
+func (c *CountingBloomFilter) Remove(key interface{}) bool {
+	if !c.MayContain(key) {
+		return false
+	}
+	c.hashes.positions(key, func(position uint64) {
+		if c.counters[position] < math.MaxUint8 {
+			c.counters[position]--
+		}
+	})
+	return true
+}
+
+// MayContain returns true if key may be in the counting bloom filter, and
+// false if it is definitely not.
+func (c *CountingBloomFilter) MayContain(key interface{}) bool {
+	found := true
+	err := c.hashes.positions(key, func(position uint64) {
+		if c.counters[position] == 0 {
+			found = false
+		}
+	})
+	return err == nil && found
+}
+
+// Union adds the keys of other to the counting bloom filter.
+//
+// both filters must have the same size, number of hash functions and seed.
+func (c *CountingBloomFilter) Union(other *CountingBloomFilter) error {
+	if err := c.hashes.compatible(other.hashes); err != nil {
+		return err
+	}
+	for i, count := range other.counters {
+		sum := int(c.counters[i]) + int(count)
+		if sum > math.MaxUint8 {
+			sum = math.MaxUint8
+		}
+		c.counters[i] = uint8(sum)
+	}
+	return nil
+}
+
+// FillRatio returns the ratio of counters that are not zero.
+func (c *CountingBloomFilter) FillRatio() float64 {
+	set := 0
+	for _, count := range c.counters {
+		if count > 0 {
+			set++
+		}
+	}
+	return float64(set) / float64(c.hashes.m)
+}
+
+// Counters returns the number of counters of the counting bloom filter.
+func (c *CountingBloomFilter) Counters() int {
+	return int(c.hashes.m)
+}
+
+// HashFunctions returns the number of counters incremented for every key.
+func (c *CountingBloomFilter) HashFunctions() int {
+	return c.hashes.k
+}
+
+// MarshalBinary encodes the counting bloom filter, the seed is part of the
+// encoding.
+func (c *CountingBloomFilter) MarshalBinary() ([]byte, error) {
+	data := c.hashes.marshalHeader(countingBloomFilterKind, len(c.counters))
+	return append(data, c.counters...), nil
+}
+
+// UnmarshalBinary decodes a counting bloom filter encoded with
+// MarshalBinary.
+//
+// the hasher of the counting bloom filter is kept, DefaultHasher is used
+// if it has none.
+func (c *CountingBloomFilter) UnmarshalBinary(data []byte) error {
+	hashes := c.hashes
+	data, err := hashes.unmarshalHeader(countingBloomFilterKind, data)
+	if err != nil {
+		return err
+	}
+	if uint64(len(data)) != hashes.m {
+		return fmt.Errorf("%w: %v counters, want %v", ErrInvalidFilterEncoding, len(data), hashes.m)
+	}
+	c.hashes = hashes
+	c.counters = append([]uint8(nil), data...)
+	return nil
+}
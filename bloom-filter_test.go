@@ -0,0 +1,228 @@
+package datastructures
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestNewBloomFilter(t *testing.T) {
+	tests := []struct {
+		name              string
+		expectedItems     int
+		falsePositiveRate float64
+		wantBits          int
+		wantHashFunctions int
+	}{
+		{name: "one percent", expectedItems: 1000, falsePositiveRate: 0.01, wantBits: 9586, wantHashFunctions: 7},
+		{name: "one in a thousand", expectedItems: 1000, falsePositiveRate: 0.001, wantBits: 14378, wantHashFunctions: 10},
+		{name: "invalid rate", expectedItems: 1000, falsePositiveRate: 2, wantBits: 9586, wantHashFunctions: 7},
+		{name: "no expected items", expectedItems: 0, falsePositiveRate: 0.01, wantBits: 10, wantHashFunctions: 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBloomFilter(tt.expectedItems, tt.falsePositiveRate)
+			if b.Bits() != tt.wantBits || b.HashFunctions() != tt.wantHashFunctions {
+				t.Errorf("NewBloomFilter() bits, hash functions = %v, %v, want %v, %v",
+					b.Bits(), b.HashFunctions(), tt.wantBits, tt.wantHashFunctions)
+			}
+		})
+	}
+}
+
+func TestBloomFilter_AddMayContain(t *testing.T) {
+	b := NewBloomFilter(1000, 0.01)
+	for i := 0; i < 1000; i++ {
+		if err := b.Add("item " + strconv.Itoa(i)); err != nil {
+			t.Fatalf("BloomFilter.Add() error = %v", err)
+		}
+	}
+	for i := 0; i < 1000; i++ {
+		if !b.MayContain("item " + strconv.Itoa(i)) {
+			t.Fatalf("BloomFilter.MayContain(%v) = false, want true", i)
+		}
+	}
+	falsePositives := 0
+	for i := 0; i < 10000; i++ {
+		if b.MayContain("other " + strconv.Itoa(i)) {
+			falsePositives++
+		}
+	}
+	// 100 false positives are expected.
+	if falsePositives > 200 {
+		t.Errorf("BloomFilter false positives = %v, want about %v", falsePositives, 100)
+	}
+	if fill := b.FillRatio(); fill < 0.4 || fill > 0.6 {
+		t.Errorf("BloomFilter.FillRatio() = %v, want about %v", fill, 0.5)
+	}
+
+	if err := b.Add([]int{1}); !errors.Is(err, ErrUnsupportedKey) {
+		t.Errorf("BloomFilter.Add() error = %v, wantErr %v", err, ErrUnsupportedKey)
+	}
+	if b.MayContain([]int{1}) {
+		t.Errorf("BloomFilter.MayContain() = true for an unsupported key")
+	}
+}
+
+func TestBloomFilter_Union(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  *BloomFilter
+		other   *BloomFilter
+		wantErr error
+	}{
+		{name: "same settings", other: NewBloomFilter(100, 0.01, HashTableSeed(1))},
+		{name: "default seeds", filter: NewBloomFilter(100, 0.01), other: NewBloomFilter(100, 0.01)},
+		{name: "different seed", other: NewBloomFilter(100, 0.01, HashTableSeed(2)), wantErr: ErrIncompatibleFilter},
+		{name: "different size", other: NewBloomFilter(200, 0.01, HashTableSeed(1)), wantErr: ErrIncompatibleFilter},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := tt.filter
+			if b == nil {
+				b = NewBloomFilter(100, 0.01, HashTableSeed(1))
+			}
+			b.Add("a")
+			tt.other.Add("b")
+			err := b.Union(tt.other)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("BloomFilter.Union() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (!b.MayContain("a") || !b.MayContain("b")) {
+				t.Errorf("BloomFilter.Union() lost keys")
+			}
+		})
+	}
+}
+
+func TestBloomFilter_MarshalBinary(t *testing.T) {
+	b := NewBloomFilter(100, 0.01, HashTableSeed(42))
+	for i := 0; i < 100; i++ {
+		b.Add(i)
+	}
+	data, err := b.MarshalBinary()
+	if err != nil {
+		t.Fatalf("BloomFilter.MarshalBinary() error = %v", err)
+	}
+	decoded := &BloomFilter{}
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("BloomFilter.UnmarshalBinary() error = %v", err)
+	}
+	for i := 0; i < 100; i++ {
+		if !decoded.MayContain(i) {
+			t.Fatalf("decoded BloomFilter.MayContain(%v) = false, want true", i)
+		}
+	}
+	if decoded.FillRatio() != b.FillRatio() {
+		t.Errorf("decoded BloomFilter.FillRatio() = %v, want %v", decoded.FillRatio(), b.FillRatio())
+	}
+	// the seed is part of the encoding, so the filters can be combined.
+	if err := decoded.Union(b); err != nil {
+		t.Errorf("BloomFilter.Union() error = %v", err)
+	}
+
+	invalid := map[string][]byte{
+		"empty":           {},
+		"truncated bits":  data[:len(data)-1],
+		"counting filter": append([]byte{countingBloomFilterKind}, data[1:]...),
+	}
+	for name, data := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if err := (&BloomFilter{}).UnmarshalBinary(data); !errors.Is(err, ErrInvalidFilterEncoding) {
+				t.Errorf("BloomFilter.UnmarshalBinary() error = %v, wantErr %v", err, ErrInvalidFilterEncoding)
+			}
+		})
+	}
+}
+
+func TestCountingBloomFilter_AddRemove(t *testing.T) {
+	c := NewCountingBloomFilter(100, 0.01)
+	for i := 0; i < 100; i++ {
+		c.Add(i)
+	}
+	for i := 0; i < 50; i++ {
+		if !c.Remove(i) {
+			t.Fatalf("CountingBloomFilter.Remove(%v) = false, want true", i)
+		}
+	}
+	for i := 50; i < 100; i++ {
+		if !c.MayContain(i) {
+			t.Fatalf("CountingBloomFilter.MayContain(%v) = false, want true", i)
+		}
+	}
+	stillThere := 0
+	for i := 0; i < 50; i++ {
+		if c.MayContain(i) {
+			stillThere++
+		}
+	}
+	if stillThere > 5 {
+		t.Errorf("%v removed keys are still in the filter", stillThere)
+	}
+	if c.Remove("never added") {
+		t.Errorf("CountingBloomFilter.Remove() = true for a key that was not added")
+	}
+
+	t.Run("duplicate keys", func(t *testing.T) {
+		c := NewCountingBloomFilter(10, 0.01)
+		c.Add("a")
+		c.Add("a")
+		c.Remove("a")
+		if !c.MayContain("a") {
+			t.Errorf("CountingBloomFilter.MayContain() = false after removing one of two adds")
+		}
+		c.Remove("a")
+		if c.MayContain("a") || c.FillRatio() != 0 {
+			t.Errorf("CountingBloomFilter is not empty after removing every add")
+		}
+	})
+
+	t.Run("saturated counters", func(t *testing.T) {
+		c := NewCountingBloomFilter(10, 0.01)
+		for i := 0; i < 300; i++ {
+			c.Add("a")
+		}
+		for i := 0; i < 300; i++ {
+			c.Remove("a")
+		}
+		// saturated counters are never decremented, so the key stays.
+		if !c.MayContain("a") {
+			t.Errorf("CountingBloomFilter.MayContain() = false with saturated counters")
+		}
+	})
+}
+
+func TestCountingBloomFilter_UnionMarshal(t *testing.T) {
+	first := NewCountingBloomFilter(100, 0.01, HashTableSeed(3))
+	second := NewCountingBloomFilter(100, 0.01, HashTableSeed(3))
+	first.Add("a")
+	second.Add("a")
+	second.Add("b")
+	if err := first.Union(second); err != nil {
+		t.Fatalf("CountingBloomFilter.Union() error = %v", err)
+	}
+	if err := first.Union(NewCountingBloomFilter(100, 0.01, HashTableSeed(4))); !errors.Is(err, ErrIncompatibleFilter) {
+		t.Errorf("CountingBloomFilter.Union() error = %v, wantErr %v", err, ErrIncompatibleFilter)
+	}
+	if err := NewCountingBloomFilter(100, 0.01).Union(NewCountingBloomFilter(100, 0.01)); err != nil {
+		t.Errorf("CountingBloomFilter.Union() of default filters error = %v", err)
+	}
+
+	data, _ := first.MarshalBinary()
+	decoded := &CountingBloomFilter{}
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("CountingBloomFilter.UnmarshalBinary() error = %v", err)
+	}
+	// "a" was added twice, once in each filter.
+	decoded.Remove("a")
+	if !decoded.MayContain("a") || !decoded.MayContain("b") {
+		t.Errorf("decoded CountingBloomFilter lost keys")
+	}
+	decoded.Remove("a")
+	if decoded.MayContain("a") {
+		t.Errorf("decoded CountingBloomFilter.MayContain() = true after removing every add")
+	}
+	if err := decoded.UnmarshalBinary(data[:len(data)-1]); !errors.Is(err, ErrInvalidFilterEncoding) {
+		t.Errorf("CountingBloomFilter.UnmarshalBinary() error = %v, wantErr %v", err, ErrInvalidFilterEncoding)
+	}
+}
@@ -0,0 +1,256 @@
+package datastructures
+
+import (
+	"fmt"
+	"math/bits"
+	"sync"
+)
+
+// defaultConcurrentHashTableShards is the number of shards used when the
+// number of shards provided is not positive.
+const defaultConcurrentHashTableShards = 32
+
+// ConcurrentHashTable represents a hash table data structure that is safe
+// for concurrent use.
+//
+// the keys are striped across independently locked shards using the hash
+// of the key, so operations on keys of different shards do not block each
+// other. each shard is a HashMap that resizes on its own.
+type ConcurrentHashTable struct {
+	shards []*concurrentHashTableShard
+	// shift is the number of bits the mixed hash of a key is shifted by
+	// to get its shard index.
+	shift  uint
+	hasher Hasher
+	seed   uint64
+}
+
+type concurrentHashTableShard struct {
+	mu      sync.RWMutex
+	hashMap *HashMap[interface{}, interface{}]
+}
+
+// NewConcurrentHashTable returns a new concurrent hash table data structure
+// with size buckets spread across shards shards.
+//
+// shards is rounded up to the next power of two.
+func NewConcurrentHashTable(size, shards int, options ...HashTableOption) *ConcurrentHashTable {
+	if shards < 1 {
+		shards = defaultConcurrentHashTableShards
+	}
+	shardsCount := 1
+	for shardsCount < shards {
+		shardsCount *= 2
+	}
+	config := newHashTableConfig(defaultHashTableMinLoadFactor, defaultHashTableMaxLoadFactor, options)
+	// every shard uses the seed of the table so that a key has the same
+	// hash in the table and in its shard.
+	shardOptions := append(options[:len(options):len(options)], HashTableSeed(config.seed))
+	shardSize := (size + shardsCount - 1) / shardsCount
+	h := &ConcurrentHashTable{
+		shards: make([]*concurrentHashTableShard, shardsCount),
+		shift:  uint(64 - bits.TrailingZeros(uint(shardsCount))),
+		hasher: config.hasher,
+		seed:   config.seed,
+	}
+	for i := range h.shards {
+		h.shards[i] = &concurrentHashTableShard{
+			hashMap: NewHashMap[interface{}, interface{}](shardSize, shardOptions...),
+		}
+	}
+	return h
+}
+
+// Set sets a new <Key, Value> item in the hash table.
+func (h *ConcurrentHashTable) Set(key interface{}, value interface{}) error {
+	shard, err := h.shard(key)
+	if err != nil {
+		return err
+	}
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	return shard.hashMap.Set(key, value)
+}
+
+// Get retrieves an item from the hash table using the key.
+func (h *ConcurrentHashTable) Get(key interface{}) (interface{}, error) {
+	shard, err := h.shard(key)
+	if err != nil {
+		return nil, err
+	}
+	shard.mu.RLock()
+	defer shard.mu.RUnlock()
+	node, _ := shard.hashMap.peek(key)
+	if node == nil {
+		return nil, fmt.Errorf("%w: %v", ErrKeyNotFound, key)
+	}
+	return node.Data.(HashTableEntry).Value, nil
+}
+
+// Delete removes an item from the hash table in key position.
+//
+// if there is no item at key position, delete does nothing.
+func (h *ConcurrentHashTable) Delete(key interface{}) {
+	shard, err := h.shard(key)
+	if err != nil {
+		return
+	}
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	shard.hashMap.Delete(key)
+}
+
+// LoadOrStore returns the value of key if it exist, else it stores value
+// and returns it.
+//
+// the returned bool is true if the value was loaded, false if it was
+// stored.
+func (h *ConcurrentHashTable) LoadOrStore(key, value interface{}) (interface{}, bool, error) {
+	shard, err := h.shard(key)
+	if err != nil {
+		return nil, false, err
+	}
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	return shard.hashMap.GetOrSet(key, value)
+}
+
+// CompareAndSwap swaps the value of key to new if its current value is
+// equal to old.
+//
+// it returns false if key is not in the hash table or its value is not old,
+// old must be of a comparable type.
+func (h *ConcurrentHashTable) CompareAndSwap(key, old, new interface{}) bool {
+	shard, err := h.shard(key)
+	if err != nil {
+		return false
+	}
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	node, _ := shard.hashMap.peek(key)
+	if node == nil {
+		return false
+	}
+	entry := node.Data.(HashTableEntry)
+	if entry.Value != old {
+		return false
+	}
+	node.Data = HashTableEntry{Key: entry.Key, Value: new}
+	return true
+}
+
+// ComputeIfAbsent returns the value of key if it exist, else it stores the
+// value returned by f and returns it.
+//
+// f is called at most once per missing key while the shard of key is
+// locked, so it must not use the hash table.
+func (h *ConcurrentHashTable) ComputeIfAbsent(key interface{}, f func() interface{}) (interface{}, error) {
+	shard, err := h.shard(key)
+	if err != nil {
+		return nil, err
+	}
+	// most calls find the key, so a read lock is tried first.
+	shard.mu.RLock()
+	node, _ := shard.hashMap.peek(key)
+	shard.mu.RUnlock()
+	if node != nil {
+		return node.Data.(HashTableEntry).Value, nil
+	}
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	var value interface{}
+	err = shard.hashMap.Update(key, func(current interface{}, exists bool) interface{} {
+		if exists {
+			value = current
+		} else {
+			value = f()
+		}
+		return value
+	})
+	return value, err
+}
+
+// Range iterates through the hash table and executes the callback function
+// f for each iteration, the iteration stops when f returns false.
+//
+// the iteration is weakly consistent: each shard is copied while it is
+// locked and f is called after the lock is released, so f may see changes
+// made during the iteration in shards it has not reached yet.
+func (h *ConcurrentHashTable) Range(f func(key, value interface{}) bool) {
+	for _, shard := range h.shards {
+		shard.mu.RLock()
+		entries := make([]HashTableEntry, 0, shard.hashMap.Elements())
+		shard.hashMap.iterate(func(key, value interface{}) bool {
+			entries = append(entries, HashTableEntry{Key: key, Value: value})
+			return true
+		})
+		shard.mu.RUnlock()
+		for _, entry := range entries {
+			if !f(entry.Key, entry.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Iterate iterates through the hash table and executes the callback function
+// f for each iteration.
+func (h *ConcurrentHashTable) Iterate(f func(key, value interface{})) {
+	h.Range(func(key, value interface{}) bool {
+		f(key, value)
+		return true
+	})
+}
+
+// Size returns the number of buckets in the hash table.
+func (h *ConcurrentHashTable) Size() int {
+	size := 0
+	for _, shard := range h.shards {
+		shard.mu.RLock()
+		size += shard.hashMap.Size()
+		shard.mu.RUnlock()
+	}
+	return size
+}
+
+// Elements returns the number of elements in the hash table.
+func (h *ConcurrentHashTable) Elements() int {
+	elements := 0
+	for _, shard := range h.shards {
+		shard.mu.RLock()
+		elements += shard.hashMap.Elements()
+		shard.mu.RUnlock()
+	}
+	return elements
+}
+
+// Stats returns the bucket chain statistics of every shard of the hash
+// table combined.
+func (h *ConcurrentHashTable) Stats() HashTableStats {
+	stats := newHashTableStats(0)
+	for _, shard := range h.shards {
+		// the shard may complete a rehash, so it is locked for writing.
+		shard.mu.Lock()
+		stats.merge(shard.hashMap.Stats())
+		shard.mu.Unlock()
+	}
+	return stats.finish()
+}
+
+// Shards returns the number of shards of the hash table.
+func (h *ConcurrentHashTable) Shards() int {
+	return len(h.shards)
+}
+
+// shard is a helper method that returns the shard of key.
+func (h *ConcurrentHashTable) shard(key interface{}) (*concurrentHashTableShard, error) {
+	keyHash, err := h.hasher.Hash(key, h.seed)
+	if err != nil {
+		return nil, err
+	}
+	// the shards use the lower bits of the hash to pick a bucket, the
+	// higher bits of the mixed hash are used to pick the shard so that
+	// the keys of a shard are still spread across its buckets. with a
+	// single shard the shift is 64 and the index is always 0.
+	return h.shards[mixHash(keyHash)>>h.shift], nil
+}
@@ -0,0 +1,280 @@
+package datastructures
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestNewConcurrentHashTable(t *testing.T) {
+	tests := []struct {
+		name       string
+		shards     int
+		wantShards int
+	}{
+		{name: "power of two shards", shards: 8, wantShards: 8},
+		{name: "rounded shards", shards: 5, wantShards: 8},
+		{name: "single shard", shards: 1, wantShards: 1},
+		{name: "default shards", shards: 0, wantShards: defaultConcurrentHashTableShards},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewConcurrentHashTable(64, tt.shards)
+			if h.Shards() != tt.wantShards {
+				t.Errorf("ConcurrentHashTable.Shards() = %v, want %v", h.Shards(), tt.wantShards)
+			}
+			for i := 0; i < 100; i++ {
+				h.Set(i, i)
+			}
+			for i := 0; i < 100; i++ {
+				if got, err := h.Get(i); err != nil || got != i {
+					t.Errorf("ConcurrentHashTable.Get(%v) = %v, %v, want %v", i, got, err, i)
+				}
+			}
+		})
+	}
+}
+
+func TestConcurrentHashTable_shards(t *testing.T) {
+	h := NewConcurrentHashTable(64, 8, HashTableSeed(3))
+	for i := 0; i < 1000; i++ {
+		h.Set(i, i)
+	}
+	for i, shard := range h.shards {
+		// 125 keys per shard are expected.
+		if elements := shard.hashMap.Elements(); elements < 75 || elements > 175 {
+			t.Errorf("shard %v has %v elements, want about %v", i, elements, 125)
+		}
+	}
+}
+
+func TestConcurrentHashTable_LoadOrStore(t *testing.T) {
+	h := NewConcurrentHashTable(16, 4)
+	const goroutines = 16
+	stored := int32(0)
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				_, loaded, err := h.LoadOrStore(i, g)
+				if err != nil {
+					t.Errorf("ConcurrentHashTable.LoadOrStore() error = %v", err)
+				}
+				if !loaded {
+					atomic.AddInt32(&stored, 1)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	if stored != 100 {
+		t.Errorf("ConcurrentHashTable.LoadOrStore() stored %v values, want %v", stored, 100)
+	}
+	if h.Elements() != 100 {
+		t.Errorf("ConcurrentHashTable.Elements() = %v, want %v", h.Elements(), 100)
+	}
+}
+
+func TestConcurrentHashTable_CompareAndSwap(t *testing.T) {
+	tests := []struct {
+		name      string
+		items     map[interface{}]interface{}
+		key       interface{}
+		old, new  interface{}
+		want      bool
+		wantValue interface{}
+	}{
+		{
+			name:      "matching value",
+			items:     map[interface{}]interface{}{"a": 1},
+			key:       "a",
+			old:       1,
+			new:       2,
+			want:      true,
+			wantValue: 2,
+		},
+		{
+			name:      "different value",
+			items:     map[interface{}]interface{}{"a": 1},
+			key:       "a",
+			old:       3,
+			new:       2,
+			wantValue: 1,
+		},
+		{
+			name: "missing key",
+			key:  "a",
+			old:  nil,
+			new:  2,
+		},
+		{
+			name: "unsupported key",
+			key:  []int{1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewConcurrentHashTable(4, 2)
+			for key, value := range tt.items {
+				h.Set(key, value)
+			}
+			if got := h.CompareAndSwap(tt.key, tt.old, tt.new); got != tt.want {
+				t.Errorf("ConcurrentHashTable.CompareAndSwap() = %v, want %v", got, tt.want)
+			}
+			if got, _ := h.Get(tt.key); got != tt.wantValue {
+				t.Errorf("ConcurrentHashTable.Get() = %v, want %v", got, tt.wantValue)
+			}
+		})
+	}
+
+	t.Run("concurrent increments", func(t *testing.T) {
+		h := NewConcurrentHashTable(4, 2)
+		h.Set("counter", 0)
+		var wg sync.WaitGroup
+		for g := 0; g < 8; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 100; i++ {
+					for {
+						value, _ := h.Get("counter")
+						if h.CompareAndSwap("counter", value, value.(int)+1) {
+							break
+						}
+					}
+				}
+			}()
+		}
+		wg.Wait()
+		if got, _ := h.Get("counter"); got != 800 {
+			t.Errorf("ConcurrentHashTable.Get() = %v, want %v", got, 800)
+		}
+	})
+}
+
+func TestConcurrentHashTable_ComputeIfAbsent(t *testing.T) {
+	h := NewConcurrentHashTable(16, 4)
+	calls := int32(0)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				value, err := h.ComputeIfAbsent(i, func() interface{} {
+					atomic.AddInt32(&calls, 1)
+					return i * 10
+				})
+				if err != nil || value != i*10 {
+					t.Errorf("ConcurrentHashTable.ComputeIfAbsent(%v) = %v, %v, want %v", i, value, err, i*10)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if calls != 50 {
+		t.Errorf("ConcurrentHashTable.ComputeIfAbsent() called f %v times, want %v", calls, 50)
+	}
+
+	_, err := h.ComputeIfAbsent([]int{1}, func() interface{} { return nil })
+	if !errors.Is(err, ErrUnsupportedKey) {
+		t.Errorf("ConcurrentHashTable.ComputeIfAbsent() error = %v, wantErr %v", err, ErrUnsupportedKey)
+	}
+}
+
+func TestConcurrentHashTable_Range(t *testing.T) {
+	t.Run("stopping the iteration", func(t *testing.T) {
+		h := NewConcurrentHashTable(16, 4)
+		for i := 0; i < 20; i++ {
+			h.Set(i, i)
+		}
+		count := 0
+		h.Range(func(key, value interface{}) bool {
+			count++
+			return count < 5
+		})
+		if count != 5 {
+			t.Errorf("ConcurrentHashTable.Range() count = %v, want %v", count, 5)
+		}
+	})
+
+	t.Run("writing while iterating", func(t *testing.T) {
+		h := NewConcurrentHashTable(16, 4)
+		for i := 0; i < 20; i++ {
+			h.Set(i, i)
+		}
+		keys := []int{}
+		// the shard locks are not held while f runs, so f can write to the
+		// hash table.
+		h.Range(func(key, value interface{}) bool {
+			keys = append(keys, key.(int))
+			h.Delete(key)
+			return true
+		})
+		sort.Ints(keys)
+		if len(keys) != 20 || keys[0] != 0 || keys[19] != 19 {
+			t.Errorf("ConcurrentHashTable.Range() keys = %v", keys)
+		}
+		if h.Elements() != 0 {
+			t.Errorf("ConcurrentHashTable.Elements() = %v, want %v", h.Elements(), 0)
+		}
+	})
+}
+
+func TestConcurrentHashTable_concurrentAccess(t *testing.T) {
+	h := NewConcurrentHashTable(8, 8)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				key := g*1000 + i
+				h.Set(key, i)
+				if got, err := h.Get(key); err != nil || got != i {
+					t.Errorf("ConcurrentHashTable.Get(%v) = %v, %v, want %v", key, got, err, i)
+				}
+				if i%2 == 0 {
+					h.Delete(key)
+				}
+				h.Range(func(key, value interface{}) bool { return false })
+			}
+		}(g)
+	}
+	wg.Wait()
+	if h.Elements() != 8*250 {
+		t.Errorf("ConcurrentHashTable.Elements() = %v, want %v", h.Elements(), 8*250)
+	}
+}
+
+func BenchmarkConcurrentHashTable_parallel(b *testing.B) {
+	b.Run("sharded", func(b *testing.B) {
+		h := NewConcurrentHashTable(1024, 0)
+		b.RunParallel(func(pb *testing.PB) {
+			i := 0
+			for pb.Next() {
+				h.Set(i%1024, i)
+				h.Get((i + 1) % 1024)
+				i++
+			}
+		})
+	})
+	b.Run("global mutex", func(b *testing.B) {
+		h := NewHashTable(1024)
+		var mu sync.Mutex
+		b.RunParallel(func(pb *testing.PB) {
+			i := 0
+			for pb.Next() {
+				mu.Lock()
+				h.Set(i%1024, i)
+				h.Get((i + 1) % 1024)
+				mu.Unlock()
+				i++
+			}
+		})
+	})
+}
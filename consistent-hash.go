@@ -0,0 +1,247 @@
+package datastructures
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+)
+
+const (
+	defaultConsistentHashVirtualNodes = 100
+	defaultConsistentHashLoadFactor   = 1.25
+)
+
+// ErrNoNodes is returned when a key is looked up in a consistent hash
+// ring that has no nodes.
+var ErrNoNodes = errors.New("consistent hash has no nodes")
+
+// ConsistentHash represents a consistent hashing ring that distributes keys
+// across nodes.
+//
+// every node is placed on the ring several times (virtual nodes) and a key
+// belongs to the first virtual node found clockwise from the hash of the
+// key. adding or removing a node only moves the keys of that node.
+//
+// the ring uses a fixed seed (0 by default), so rings with the same nodes
+// map keys to the same nodes in every process.
+type ConsistentHash struct {
+	virtualNodes int
+	hasher       Hasher
+	seed         uint64
+	loadFactor   float64
+	// ring holds the virtual nodes sorted by hash.
+	ring []consistentHashPoint
+	// loads holds the number of keys acquired by every node.
+	loads     map[string]int
+	totalLoad int
+}
+
+type consistentHashPoint struct {
+	hash uint64
+	node string
+}
+
+// ConsistentHashOption configures a ring created with NewConsistentHash.
+type ConsistentHashOption func(c *ConsistentHash)
+
+// ConsistentHashHasher sets the Hasher used to hash the keys and the
+// virtual nodes, DefaultHasher is used by default.
+func ConsistentHashHasher(hasher Hasher) ConsistentHashOption {
+	return func(c *ConsistentHash) {
+		c.hasher = hasher
+	}
+}
+
+// ConsistentHashSeed sets the seed used to hash the keys and the virtual
+// nodes.
+func ConsistentHashSeed(seed uint64) ConsistentHashOption {
+	return func(c *ConsistentHash) {
+		c.seed = seed
+	}
+}
+
+// ConsistentHashLoadFactor sets how much more than the average load a node
+// can take in bounded-load mode (see Acquire), it must be above 1 and is
+// 1.25 by default.
+func ConsistentHashLoadFactor(loadFactor float64) ConsistentHashOption {
+	return func(c *ConsistentHash) {
+		if loadFactor > 1 {
+			c.loadFactor = loadFactor
+		}
+	}
+}
+
+// NewConsistentHash returns a new consistent hashing ring that places every
+// node virtualNodes times on the ring.
+//
+// 100 virtual nodes are used when virtualNodes is not positive.
+func NewConsistentHash(virtualNodes int, options ...ConsistentHashOption) *ConsistentHash {
+	if virtualNodes < 1 {
+		virtualNodes = defaultConsistentHashVirtualNodes
+	}
+	c := &ConsistentHash{
+		virtualNodes: virtualNodes,
+		hasher:       DefaultHasher,
+		loadFactor:   defaultConsistentHashLoadFactor,
+		loads:        map[string]int{},
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// AddNode adds node to the ring.
+//
+// if the node is already in the ring, AddNode does nothing.
+func (c *ConsistentHash) AddNode(node string) error {
+	if _, exists := c.loads[node]; exists {
+		return nil
+	}
+	points := make([]consistentHashPoint, 0, c.virtualNodes)
+	for i := 0; i < c.virtualNodes; i++ {
+		hash, err := c.hasher.Hash(node+"#"+strconv.Itoa(i), c.seed)
+		if err != nil {
+			return err
+		}
+		points = append(points, consistentHashPoint{hash: hash, node: node})
+	}
+	c.ring = append(c.ring, points...)
+	// virtual nodes with the same hash are ordered by node so that the
+	// ring does not depend on the order the nodes were added in.
+	sort.Slice(c.ring, func(i, j int) bool {
+		if c.ring[i].hash != c.ring[j].hash {
+			return c.ring[i].hash < c.ring[j].hash
+		}
+		return c.ring[i].node < c.ring[j].node
+	})
+	c.loads[node] = 0
+	return nil
+}
+
+// RemoveNode removes node from the ring.
+//
+// it returns false if the node is not in the ring.
+func (c *ConsistentHash) RemoveNode(node string) bool {
+	load, exists := c.loads[node]
+	if !exists {
+		return false
+	}
+	ring := c.ring[:0]
+	for _, point := range c.ring {
+		if point.node != node {
+			ring = append(ring, point)
+		}
+	}
+	c.ring = ring
+	c.totalLoad -= load
+	delete(c.loads, node)
+	return true
+}
+
+// Get returns the node of key.
+func (c *ConsistentHash) Get(key interface{}) (string, error) {
+	index, err := c.search(key)
+	if err != nil {
+		return "", err
+	}
+	return c.ring[index].node, nil
+}
+
+// GetN returns the n distinct nodes found clockwise from key, the first
+// node is the node of key. it is used to pick the replicas of a key.
+//
+// fewer than n nodes are returned when the ring has fewer than n nodes, and
+// none when n is not positive.
+func (c *ConsistentHash) GetN(key interface{}, n int) ([]string, error) {
+	index, err := c.search(key)
+	if err != nil {
+		return nil, err
+	}
+	n = min(max(n, 0), len(c.loads))
+	nodes := make([]string, 0, n)
+	seen := map[string]bool{}
+	for i := 0; len(nodes) < n; i++ {
+		node := c.ring[(index+i)%len(c.ring)].node
+		if !seen[node] {
+			seen[node] = true
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes, nil
+}
+
+// Acquire returns the node of key in bounded-load mode and adds one to the
+// load of the node, Release must be called with the node once the key is
+// done.
+//
+// a node cannot take more than the load factor times the average load, the
+// key goes to the next node clockwise that is below that bound.
+func (c *ConsistentHash) Acquire(key interface{}) (string, error) {
+	index, err := c.search(key)
+	if err != nil {
+		return "", err
+	}
+	maxLoad := c.MaxLoad()
+	for i := 0; ; i++ {
+		node := c.ring[(index+i)%len(c.ring)].node
+		if c.loads[node] < maxLoad {
+			c.loads[node]++
+			c.totalLoad++
+			return node, nil
+		}
+	}
+}
+
+// Release removes one from the load of node.
+func (c *ConsistentHash) Release(node string) {
+	if c.loads[node] > 0 {
+		c.loads[node]--
+		c.totalLoad--
+	}
+}
+
+// Load returns the number of keys acquired by node.
+func (c *ConsistentHash) Load(node string) int {
+	return c.loads[node]
+}
+
+// MaxLoad returns the number of keys a node can take in bounded-load mode
+// before the next key is acquired.
+func (c *ConsistentHash) MaxLoad() int {
+	if len(c.loads) == 0 {
+		return 0
+	}
+	return int(math.Ceil(c.loadFactor * float64(c.totalLoad+1) / float64(len(c.loads))))
+}
+
+// Nodes returns the nodes of the ring sorted by name.
+func (c *ConsistentHash) Nodes() []string {
+	nodes := make([]string, 0, len(c.loads))
+	for node := range c.loads {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
+// search is a helper method that returns the ring index of the first
+// virtual node clockwise from the hash of key.
+func (c *ConsistentHash) search(key interface{}) (int, error) {
+	if len(c.ring) == 0 {
+		return 0, ErrNoNodes
+	}
+	hash, err := c.hasher.Hash(key, c.seed)
+	if err != nil {
+		return 0, err
+	}
+	index := sort.Search(len(c.ring), func(i int) bool {
+		return c.ring[i].hash >= hash
+	})
+	// the ring wraps around after its last virtual node.
+	if index == len(c.ring) {
+		index = 0
+	}
+	return index, nil
+}
@@ -0,0 +1,229 @@
+package datastructures
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func newConsistentHashWithNodes(t *testing.T, nodes ...string) *ConsistentHash {
+	t.Helper()
+	c := NewConsistentHash(100)
+	for _, node := range nodes {
+		if err := c.AddNode(node); err != nil {
+			t.Fatalf("ConsistentHash.AddNode() error = %v", err)
+		}
+	}
+	return c
+}
+
+func consistentHashAssignments(t *testing.T, c *ConsistentHash, keys int) map[string]string {
+	t.Helper()
+	assignments := map[string]string{}
+	for i := 0; i < keys; i++ {
+		key := "key " + strconv.Itoa(i)
+		node, err := c.Get(key)
+		if err != nil {
+			t.Fatalf("ConsistentHash.Get() error = %v", err)
+		}
+		assignments[key] = node
+	}
+	return assignments
+}
+
+func TestConsistentHash_Get(t *testing.T) {
+	t.Run("empty ring", func(t *testing.T) {
+		c := NewConsistentHash(10)
+		if _, err := c.Get("key"); !errors.Is(err, ErrNoNodes) {
+			t.Errorf("ConsistentHash.Get() error = %v, wantErr %v", err, ErrNoNodes)
+		}
+	})
+
+	t.Run("unsupported key", func(t *testing.T) {
+		c := newConsistentHashWithNodes(t, "a")
+		if _, err := c.Get([]int{1}); !errors.Is(err, ErrUnsupportedKey) {
+			t.Errorf("ConsistentHash.Get() error = %v, wantErr %v", err, ErrUnsupportedKey)
+		}
+	})
+
+	t.Run("same ring in different orders", func(t *testing.T) {
+		first := newConsistentHashWithNodes(t, "a", "b", "c")
+		second := newConsistentHashWithNodes(t, "c", "a", "b")
+		if !reflect.DeepEqual(consistentHashAssignments(t, first, 1000), consistentHashAssignments(t, second, 1000)) {
+			t.Errorf("ConsistentHash.Get() depends on the order the nodes were added in")
+		}
+	})
+
+	t.Run("integer keys", func(t *testing.T) {
+		c := newConsistentHashWithNodes(t, "a", "b")
+		first, _ := c.Get(42)
+		second, _ := c.Get(42)
+		if first != second {
+			t.Errorf("ConsistentHash.Get() = %v, want %v", second, first)
+		}
+	})
+}
+
+func TestConsistentHash_distribution(t *testing.T) {
+	nodes := []string{"a", "b", "c", "d", "e"}
+	c := newConsistentHashWithNodes(t, nodes...)
+	counts := map[string]int{}
+	for _, node := range consistentHashAssignments(t, c, 10000) {
+		counts[node]++
+	}
+	for _, node := range nodes {
+		// 2000 keys per node are expected.
+		if counts[node] < 1400 || counts[node] > 2600 {
+			t.Errorf("node %v has %v keys, want about %v", node, counts[node], 2000)
+		}
+	}
+}
+
+func TestConsistentHash_keyMovement(t *testing.T) {
+	const keys = 10000
+	nodes := []string{}
+	for i := 0; i < 10; i++ {
+		nodes = append(nodes, "node "+strconv.Itoa(i))
+	}
+
+	t.Run("adding a node", func(t *testing.T) {
+		c := newConsistentHashWithNodes(t, nodes...)
+		before := consistentHashAssignments(t, c, keys)
+		c.AddNode("new node")
+		after := consistentHashAssignments(t, c, keys)
+		moved := 0
+		for key, node := range after {
+			if node == before[key] {
+				continue
+			}
+			moved++
+			if node != "new node" {
+				t.Fatalf("key %v moved from %v to %v, want it to move to the new node", key, before[key], node)
+			}
+		}
+		// keys/11 keys are expected to move.
+		if moved == 0 || moved > 2*keys/11 {
+			t.Errorf("%v keys moved, want about %v", moved, keys/11)
+		}
+	})
+
+	t.Run("removing a node", func(t *testing.T) {
+		c := newConsistentHashWithNodes(t, nodes...)
+		before := consistentHashAssignments(t, c, keys)
+		if !c.RemoveNode("node 3") {
+			t.Fatalf("ConsistentHash.RemoveNode() = false, want true")
+		}
+		after := consistentHashAssignments(t, c, keys)
+		for key, node := range after {
+			if before[key] != "node 3" && node != before[key] {
+				t.Fatalf("key %v moved from %v to %v, want only the keys of the removed node to move", key, before[key], node)
+			}
+			if node == "node 3" {
+				t.Fatalf("key %v is on the removed node", key)
+			}
+		}
+	})
+}
+
+func TestConsistentHash_AddRemoveNode(t *testing.T) {
+	c := newConsistentHashWithNodes(t, "a", "b", "a")
+	if got := c.Nodes(); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("ConsistentHash.Nodes() = %v, want %v", got, []string{"a", "b"})
+	}
+	if len(c.ring) != 200 {
+		t.Errorf("ConsistentHash ring has %v virtual nodes, want %v", len(c.ring), 200)
+	}
+	if c.RemoveNode("c") {
+		t.Errorf("ConsistentHash.RemoveNode() = true, want false")
+	}
+	c.RemoveNode("a")
+	c.RemoveNode("b")
+	if _, err := c.Get("key"); !errors.Is(err, ErrNoNodes) {
+		t.Errorf("ConsistentHash.Get() error = %v, wantErr %v", err, ErrNoNodes)
+	}
+}
+
+func TestConsistentHash_GetN(t *testing.T) {
+	tests := []struct {
+		name      string
+		nodes     []string
+		n         int
+		wantNodes int
+		wantErr   error
+	}{
+		{name: "fewer replicas than nodes", nodes: []string{"a", "b", "c", "d"}, n: 3, wantNodes: 3},
+		{name: "more replicas than nodes", nodes: []string{"a", "b"}, n: 3, wantNodes: 2},
+		{name: "no replicas", nodes: []string{"a", "b"}, n: 0, wantNodes: 0},
+		{name: "negative replicas", nodes: []string{"a", "b"}, n: -1, wantNodes: 0},
+		{name: "empty ring", n: 2, wantErr: ErrNoNodes},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newConsistentHashWithNodes(t, tt.nodes...)
+			got, err := c.GetN("key", tt.n)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ConsistentHash.GetN() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != tt.wantNodes {
+				t.Fatalf("ConsistentHash.GetN() = %v, want %v nodes", got, tt.wantNodes)
+			}
+			seen := map[string]bool{}
+			for _, node := range got {
+				if seen[node] {
+					t.Errorf("ConsistentHash.GetN() = %v, want distinct nodes", got)
+				}
+				seen[node] = true
+			}
+			if first, _ := c.Get("key"); len(got) > 0 && got[0] != first {
+				t.Errorf("ConsistentHash.GetN()[0] = %v, want %v", got[0], first)
+			}
+		})
+	}
+}
+
+func TestConsistentHash_Acquire(t *testing.T) {
+	c := NewConsistentHash(100, ConsistentHashLoadFactor(1.25))
+	for _, node := range []string{"a", "b", "c", "d"} {
+		c.AddNode(node)
+	}
+	acquired := []string{}
+	for i := 0; i < 1000; i++ {
+		// the same key would put every load on a single node without
+		// the bound.
+		node, err := c.Acquire("hot key")
+		if err != nil {
+			t.Fatalf("ConsistentHash.Acquire() error = %v", err)
+		}
+		acquired = append(acquired, node)
+	}
+	for _, node := range c.Nodes() {
+		// the bound is ceil(1.25 * 1000 / 4).
+		if c.Load(node) > 313 {
+			t.Errorf("ConsistentHash.Load(%v) = %v, want at most %v", node, c.Load(node), 313)
+		}
+	}
+	for _, node := range acquired {
+		c.Release(node)
+	}
+	for _, node := range c.Nodes() {
+		if c.Load(node) != 0 {
+			t.Errorf("ConsistentHash.Load(%v) = %v, want %v", node, c.Load(node), 0)
+		}
+	}
+	if first, _ := c.Acquire("hot key"); first != acquired[0] {
+		t.Errorf("ConsistentHash.Acquire() = %v, want the node of the key %v", first, acquired[0])
+	}
+}
+
+func TestConsistentHashSeed(t *testing.T) {
+	first := NewConsistentHash(50, ConsistentHashSeed(1))
+	second := NewConsistentHash(50, ConsistentHashSeed(2))
+	for _, node := range []string{"a", "b", "c"} {
+		first.AddNode(node)
+		second.AddNode(node)
+	}
+	if reflect.DeepEqual(consistentHashAssignments(t, first, 1000), consistentHashAssignments(t, second, 1000)) {
+		t.Errorf("ConsistentHash.Get() does not depend on the seed")
+	}
+}
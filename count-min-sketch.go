@@ -0,0 +1,339 @@
+package datastructures
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+)
+
+const (
+	// countMinSketchHeaderSize is the size of the encoding header: a kind
+	// byte, a version byte, the width, the depth, the seed, the update
+	// mode, the total and the number of top-k keys.
+	countMinSketchHeaderSize      = 2 + 4 + 4 + 8 + 1 + 8 + 4 + 4
+	countMinSketchKind       byte = 'M'
+)
+
+// CountMinSketchItem is a key tracked by a count-min sketch and its
+// estimated count.
+type CountMinSketchItem struct {
+	Key   interface{}
+	Count uint64
+}
+
+// CountMinSketch represents a count-min sketch data structure, a sketch
+// that estimates how many times every key was added.
+//
+// an estimate is never below the real count and it is above the real count
+// by at most epsilon * Total() with probability 1 - delta.
+type CountMinSketch struct {
+	width        int
+	depth        int
+	counters     []uint64
+	total        uint64
+	conservative bool
+	// topK is the number of heavy hitters tracked, 0 disables tracking.
+	topK int
+	// topCounts holds the tracked keys and their estimated counts ordered
+	// by count, so that the smallest one is found quickly, and topKeys
+	// holds the handle of every tracked key in topCounts.
+	topKeys   *HashMap[interface{}, HeapHandle[*CountMinSketchItem]]
+	topCounts *Heap[*CountMinSketchItem]
+	hasher    Hasher
+	seed      uint64
+}
+
+// NewCountMinSketch returns a new count-min sketch with an error of at most
+// epsilon * Total() with probability 1 - delta.
+//
+// the topK keys with the largest counts are tracked, see TopK. the seed is
+// 0 unless the HashTableSeed option is used, sketches must have the same
+// size and seed to be merged.
+func NewCountMinSketch(epsilon, delta float64, topK int, options ...HashTableOption) *CountMinSketch {
+	return newCountMinSketch(epsilon, delta, topK, false, options)
+}
+
+// NewConservativeCountMinSketch returns a new count-min sketch that uses
+// conservative updates: an Add only increments the counters of the key that
+// are below its new estimate, which makes the estimates more accurate.
+func NewConservativeCountMinSketch(epsilon, delta float64, topK int, options ...HashTableOption) *CountMinSketch {
+	return newCountMinSketch(epsilon, delta, topK, true, options)
+}
+
+func newCountMinSketch(epsilon, delta float64, topK int, conservative bool, options []HashTableOption) *CountMinSketch {
+	if epsilon <= 0 || epsilon >= 1 {
+		epsilon = 0.001
+	}
+	if delta <= 0 || delta >= 1 {
+		delta = 0.01
+	}
+	config := newHashTableConfig(0, 0, sketchOptions(options))
+	width := int(math.Ceil(math.E / epsilon))
+	depth := int(math.Ceil(math.Log(1 / delta)))
+	c := &CountMinSketch{
+		width:        width,
+		depth:        depth,
+		counters:     make([]uint64, width*depth),
+		conservative: conservative,
+		hasher:       config.hasher,
+		seed:         config.seed,
+	}
+	c.setTopK(topK)
+	return c
+}
+
+// Add adds count occurrences of key to the count-min sketch.
+func (c *CountMinSketch) Add(key interface{}, count uint64) error {
+	columns, err := c.columns(key)
+	if err != nil {
+		return err
+	}
+	c.total += count
+	if c.conservative {
+		estimate := c.estimate(columns) + count
+		for row, column := range columns {
+			counter := &c.counters[row*c.width+column]
+			if *counter < estimate {
+				*counter = estimate
+			}
+		}
+	} else {
+		for row, column := range columns {
+			c.counters[row*c.width+column] += count
+		}
+	}
+	c.track(key, c.estimate(columns))
+	return nil
+}
+
+// Estimate returns the estimated number of occurrences of key.
+func (c *CountMinSketch) Estimate(key interface{}) uint64 {
+	columns, err := c.columns(key)
+	if err != nil {
+		return 0
+	}
+	return c.estimate(columns)
+}
+
+// Total returns the number of occurrences added to the count-min sketch.
+func (c *CountMinSketch) Total() uint64 {
+	return c.total
+}
+
+// TopK returns the tracked keys with the largest estimated counts, sorted
+// by count from the largest.
+func (c *CountMinSketch) TopK() []CountMinSketchItem {
+	if c.topKeys == nil {
+		return nil
+	}
+	items := make([]CountMinSketchItem, 0, c.topKeys.Elements())
+	c.topKeys.Range(func(_ interface{}, handle HeapHandle[*CountMinSketchItem]) bool {
+		items = append(items, *handle.Item())
+		return true
+	})
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Count > items[j].Count
+	})
+	return items
+}
+
+// Merge adds the occurrences of other to the count-min sketch.
+//
+// both sketches must have the same size, seed and update mode. the tracked
+// keys of both sketches are tracked again with their merged estimates.
+func (c *CountMinSketch) Merge(other *CountMinSketch) error {
+	if c.width != other.width || c.depth != other.depth || c.seed != other.seed {
+		return fmt.Errorf(
+			"%w: (%vx%v, seed %v) and (%vx%v, seed %v)",
+			ErrIncompatibleSketch, c.width, c.depth, c.seed, other.width, other.depth, other.seed,
+		)
+	}
+	// the counters of a conservative sketch are not the sums of the adds
+	// that hit them, they cannot be mixed with standard counters.
+	if c.conservative != other.conservative {
+		return fmt.Errorf(
+			"%w: conservative update %v and %v",
+			ErrIncompatibleSketch, c.conservative, other.conservative,
+		)
+	}
+	for i, count := range other.counters {
+		c.counters[i] += count
+	}
+	c.total += other.total
+	candidates := append(c.TopK(), other.TopK()...)
+	c.setTopK(c.topK)
+	for _, item := range candidates {
+		c.track(item.Key, c.Estimate(item.Key))
+	}
+	return nil
+}
+
+// MarshalBinary encodes the count-min sketch, the seed is part of the
+// encoding.
+//
+// the tracked keys are encoded too, it returns ErrUnsupportedKey if one of
+// them is not a string.
+func (c *CountMinSketch) MarshalBinary() ([]byte, error) {
+	topK := c.TopK()
+	data := make([]byte, countMinSketchHeaderSize, countMinSketchHeaderSize+len(c.counters)*8)
+	data[0] = countMinSketchKind
+	data[1] = sketchEncodingVersion
+	binary.BigEndian.PutUint32(data[2:], uint32(c.width))
+	binary.BigEndian.PutUint32(data[6:], uint32(c.depth))
+	binary.BigEndian.PutUint64(data[10:], c.seed)
+	if c.conservative {
+		data[18] = 1
+	}
+	binary.BigEndian.PutUint64(data[19:], c.total)
+	binary.BigEndian.PutUint32(data[27:], uint32(c.topK))
+	binary.BigEndian.PutUint32(data[31:], uint32(len(topK)))
+	for _, counter := range c.counters {
+		data = binary.BigEndian.AppendUint64(data, counter)
+	}
+	for _, item := range topK {
+		key, ok := item.Key.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: only string keys can be encoded, got %v", ErrUnsupportedKey, reflect.TypeOf(item.Key))
+		}
+		data = binary.BigEndian.AppendUint32(data, uint32(len(key)))
+		data = append(data, key...)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary decodes a count-min sketch encoded with MarshalBinary.
+//
+// the hasher of the count-min sketch is kept, DefaultHasher is used if it
+// has none.
+func (c *CountMinSketch) UnmarshalBinary(data []byte) error {
+	if len(data) < countMinSketchHeaderSize || data[0] != countMinSketchKind || data[1] != sketchEncodingVersion {
+		return fmt.Errorf("%w: bad header", ErrInvalidSketchEncoding)
+	}
+	width := int(binary.BigEndian.Uint32(data[2:]))
+	depth := int(binary.BigEndian.Uint32(data[6:]))
+	topKeysCount := int(binary.BigEndian.Uint32(data[31:]))
+	rest := data[countMinSketchHeaderSize:]
+	if width == 0 || depth == 0 || len(rest)/8/width < depth {
+		return fmt.Errorf("%w: %vx%v counters in %v bytes", ErrInvalidSketchEncoding, width, depth, len(rest))
+	}
+	decoded := &CountMinSketch{
+		width:        width,
+		depth:        depth,
+		counters:     make([]uint64, width*depth),
+		conservative: data[18] == 1,
+		total:        binary.BigEndian.Uint64(data[19:]),
+		hasher:       c.hasher,
+		seed:         binary.BigEndian.Uint64(data[10:]),
+	}
+	for i := range decoded.counters {
+		decoded.counters[i] = binary.BigEndian.Uint64(rest[i*8:])
+	}
+	rest = rest[len(decoded.counters)*8:]
+	decoded.setTopK(int(binary.BigEndian.Uint32(data[27:])))
+	for i := 0; i < topKeysCount; i++ {
+		if len(rest) < 4 || uint64(len(rest)-4) < uint64(binary.BigEndian.Uint32(rest)) {
+			return fmt.Errorf("%w: truncated top-k keys", ErrInvalidSketchEncoding)
+		}
+		length := int(binary.BigEndian.Uint32(rest))
+		key := string(rest[4 : 4+length])
+		rest = rest[4+length:]
+		decoded.track(key, decoded.Estimate(key))
+	}
+	if len(rest) != 0 {
+		return fmt.Errorf("%w: %v trailing bytes", ErrInvalidSketchEncoding, len(rest))
+	}
+	*c = *decoded
+	return nil
+}
+
+// columns is a helper method that returns the column of key in every row.
+//
+// the columns are built from two hashes of the key like the positions of a
+// bloom filter.
+func (c *CountMinSketch) columns(key interface{}) ([]int, error) {
+	first, err := c.hasherOrDefault().Hash(key, c.seed)
+	if err != nil {
+		return nil, err
+	}
+	second := mixHash(first) | 1
+	columns := make([]int, c.depth)
+	for row := range columns {
+		columns[row] = int((first + uint64(row)*second) % uint64(c.width))
+	}
+	return columns, nil
+}
+
+// estimate is a helper method that returns the smallest counter of the
+// columns of a key.
+func (c *CountMinSketch) estimate(columns []int) uint64 {
+	estimate := uint64(math.MaxUint64)
+	for row, column := range columns {
+		if counter := c.counters[row*c.width+column]; counter < estimate {
+			estimate = counter
+		}
+	}
+	return estimate
+}
+
+// setTopK is a helper method that resets the tracked keys and tracks up
+// to topK keys.
+func (c *CountMinSketch) setTopK(topK int) {
+	if topK < 0 {
+		topK = 0
+	}
+	c.topK = topK
+	c.topKeys = nil
+	c.topCounts = nil
+	if topK > 0 {
+		// the map grows with the tracked keys, topK can be large.
+		size := topK
+		if size > 64 {
+			size = 64
+		}
+		c.topKeys = NewHashMap[interface{}, HeapHandle[*CountMinSketchItem]](
+			size, HashTableHasher(c.hasherOrDefault()), HashTableSeed(c.seed),
+		)
+		c.topCounts = NewHeap(func(a, b *CountMinSketchItem) bool {
+			return a.Count < b.Count
+		})
+	}
+}
+
+// track is a helper method that updates the estimated count of key in the
+// tracked keys, it replaces the tracked key with the smallest count when
+// count is larger.
+func (c *CountMinSketch) track(key interface{}, count uint64) {
+	if c.topK == 0 {
+		return
+	}
+	if handle, found := c.topKeys.Get(key); found {
+		// the count can grow, so the item is pushed again rather than
+		// decreased in place.
+		item := handle.Item()
+		c.topCounts.Delete(handle)
+		item.Count = count
+		c.topKeys.Set(key, c.topCounts.Push(item))
+		return
+	}
+	if c.topKeys.Elements() == c.topK {
+		smallest, _ := c.topCounts.Peek()
+		if count <= smallest.Count {
+			return
+		}
+		c.topCounts.Poll()
+		c.topKeys.Delete(smallest.Key)
+	}
+	item := &CountMinSketchItem{Key: key, Count: count}
+	c.topKeys.Set(key, c.topCounts.Push(item))
+}
+
+// hasherOrDefault is a helper method that returns the hasher of the
+// count-min sketch or DefaultHasher if it has none.
+func (c *CountMinSketch) hasherOrDefault() Hasher {
+	if c.hasher == nil {
+		return DefaultHasher
+	}
+	return c.hasher
+}
@@ -0,0 +1,177 @@
+package datastructures
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// zipfStream adds key i (i+1)^-1 * 10000 times for every i below keys, so
+// that key 0 is the heaviest hitter.
+func zipfStream(c *CountMinSketch, prefix string, keys int) map[string]uint64 {
+	counts := map[string]uint64{}
+	for i := 0; i < keys; i++ {
+		key := prefix + strconv.Itoa(i)
+		count := uint64(10000 / (i + 1))
+		c.Add(key, count)
+		counts[key] = count
+	}
+	return counts
+}
+
+func TestCountMinSketch_Estimate(t *testing.T) {
+	tests := []struct {
+		name   string
+		sketch *CountMinSketch
+	}{
+		{name: "standard updates", sketch: NewCountMinSketch(0.001, 0.01, 0)},
+		{name: "conservative updates", sketch: NewConservativeCountMinSketch(0.001, 0.01, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counts := zipfStream(tt.sketch, "key ", 5000)
+			total := uint64(0)
+			for _, count := range counts {
+				total += count
+			}
+			if tt.sketch.Total() != total {
+				t.Errorf("CountMinSketch.Total() = %v, want %v", tt.sketch.Total(), total)
+			}
+			maxError := uint64(0.001 * float64(total))
+			for key, count := range counts {
+				estimate := tt.sketch.Estimate(key)
+				if estimate < count || estimate > count+maxError {
+					t.Fatalf("CountMinSketch.Estimate(%v) = %v, want between %v and %v", key, estimate, count, count+maxError)
+				}
+			}
+			if got := tt.sketch.Estimate([]int{1}); got != 0 {
+				t.Errorf("CountMinSketch.Estimate() = %v for an unsupported key", got)
+			}
+		})
+	}
+}
+
+func TestCountMinSketch_conservativeUpdate(t *testing.T) {
+	standard := NewCountMinSketch(0.01, 0.1, 0)
+	conservative := NewConservativeCountMinSketch(0.01, 0.1, 0)
+	zipfStream(standard, "key ", 3000)
+	counts := zipfStream(conservative, "key ", 3000)
+	standardError, conservativeError := uint64(0), uint64(0)
+	for key, count := range counts {
+		standardError += standard.Estimate(key) - count
+		conservativeError += conservative.Estimate(key) - count
+	}
+	if conservativeError >= standardError {
+		t.Errorf("conservative error = %v, want less than the standard error %v", conservativeError, standardError)
+	}
+}
+
+func TestCountMinSketch_TopK(t *testing.T) {
+	c := NewCountMinSketch(0.001, 0.01, 3)
+	// the heavy hitters are added in small increments mixed with the
+	// other keys.
+	for round := 0; round < 100; round++ {
+		c.Add("heavy", 10)
+		c.Add("medium", 5)
+		c.Add("light", 2)
+		for i := 0; i < 10; i++ {
+			c.Add("noise "+strconv.Itoa(round*10+i), 1)
+		}
+	}
+	want := []CountMinSketchItem{{Key: "heavy", Count: 1000}, {Key: "medium", Count: 500}, {Key: "light", Count: 200}}
+	if got := c.TopK(); !reflect.DeepEqual(got, want) {
+		t.Errorf("CountMinSketch.TopK() = %v, want %v", got, want)
+	}
+	if c.topCounts.Size() != 3 {
+		t.Errorf("CountMinSketch top counts heap has %v items, want %v", c.topCounts.Size(), 3)
+	}
+	if got := NewCountMinSketch(0.1, 0.1, 0).TopK(); got != nil {
+		t.Errorf("CountMinSketch.TopK() = %v without tracking, want nil", got)
+	}
+}
+
+func TestCountMinSketch_TopK_largeCounts(t *testing.T) {
+	// 1<<53+1 is not exact in a float64, it must still replace 1<<53.
+	c := NewCountMinSketch(0.001, 0.01, 1)
+	c.Add("a", 1<<53)
+	c.Add("b", 1<<53+1)
+	want := []CountMinSketchItem{{Key: "b", Count: 1<<53 + 1}}
+	if got := c.TopK(); !reflect.DeepEqual(got, want) {
+		t.Errorf("CountMinSketch.TopK() = %v, want %v", got, want)
+	}
+}
+
+func TestCountMinSketch_Merge(t *testing.T) {
+	first := NewCountMinSketch(0.001, 0.01, 2)
+	second := NewCountMinSketch(0.001, 0.01, 2)
+	first.Add("a", 50)
+	first.Add("b", 40)
+	second.Add("c", 60)
+	second.Add("b", 30)
+	if err := first.Merge(second); err != nil {
+		t.Fatalf("CountMinSketch.Merge() error = %v", err)
+	}
+	if first.Estimate("b") != 70 || first.Total() != 180 {
+		t.Errorf("CountMinSketch.Estimate(b), Total() = %v, %v, want %v, %v", first.Estimate("b"), first.Total(), 70, 180)
+	}
+	want := []CountMinSketchItem{{Key: "b", Count: 70}, {Key: "c", Count: 60}}
+	if got := first.TopK(); !reflect.DeepEqual(got, want) {
+		t.Errorf("CountMinSketch.TopK() = %v after merge, want %v", got, want)
+	}
+
+	incompatible := []*CountMinSketch{
+		NewCountMinSketch(0.01, 0.01, 2),
+		NewCountMinSketch(0.001, 0.01, 2, HashTableSeed(9)),
+		NewConservativeCountMinSketch(0.001, 0.01, 2),
+	}
+	for _, other := range incompatible {
+		if err := first.Merge(other); !errors.Is(err, ErrIncompatibleSketch) {
+			t.Errorf("CountMinSketch.Merge() error = %v, wantErr %v", err, ErrIncompatibleSketch)
+		}
+	}
+}
+
+func TestCountMinSketch_MarshalBinary(t *testing.T) {
+	c := NewConservativeCountMinSketch(0.01, 0.01, 2)
+	zipfStream(c, "key ", 100)
+	data, err := c.MarshalBinary()
+	if err != nil {
+		t.Fatalf("CountMinSketch.MarshalBinary() error = %v", err)
+	}
+	decoded := &CountMinSketch{}
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("CountMinSketch.UnmarshalBinary() error = %v", err)
+	}
+	for i := 0; i < 100; i++ {
+		key := "key " + strconv.Itoa(i)
+		if decoded.Estimate(key) != c.Estimate(key) {
+			t.Fatalf("decoded CountMinSketch.Estimate(%v) = %v, want %v", key, decoded.Estimate(key), c.Estimate(key))
+		}
+	}
+	if !reflect.DeepEqual(decoded.TopK(), c.TopK()) || !decoded.conservative || decoded.Total() != c.Total() {
+		t.Errorf("decoded CountMinSketch = %v, %v, %v, want %v, %v, %v",
+			decoded.TopK(), decoded.conservative, decoded.Total(), c.TopK(), true, c.Total())
+	}
+
+	t.Run("non string keys", func(t *testing.T) {
+		c := NewCountMinSketch(0.1, 0.1, 1)
+		c.Add(1, 1)
+		if _, err := c.MarshalBinary(); !errors.Is(err, ErrUnsupportedKey) {
+			t.Errorf("CountMinSketch.MarshalBinary() error = %v, wantErr %v", err, ErrUnsupportedKey)
+		}
+	})
+
+	invalid := map[string][]byte{
+		"empty":          {},
+		"truncated":      data[:len(data)-1],
+		"trailing bytes": append(append([]byte{}, data...), 0),
+	}
+	for name, data := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if err := (&CountMinSketch{}).UnmarshalBinary(data); !errors.Is(err, ErrInvalidSketchEncoding) {
+				t.Errorf("CountMinSketch.UnmarshalBinary() error = %v, wantErr %v", err, ErrInvalidSketchEncoding)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package datastructures
+
+import (
+	"errors"
+	"math/rand"
+)
+
+const (
+	defaultCuckooBucketSize = 4
+	defaultCuckooMaxKicks   = 500
+)
+
+// ErrFilterFull is returned when a key cannot be added to a cuckoo filter
+// because it is full.
+var ErrFilterFull = errors.New("filter is full")
+
+// CuckooFilter represents a cuckoo filter data structure, a probabilistic
+// set that supports deletes and uses less space than a counting bloom
+// filter.
+//
+// the filter stores a 16 bit fingerprint of every key in one of two
+// candidate buckets, a key that finds both buckets full kicks a fingerprint
+// to its other bucket. like a bloom filter, MayContain can return true for
+// a key that was not added.
+type CuckooFilter struct {
+	// buckets holds the fingerprints of every bucket one after the other,
+	// 0 marks an empty slot.
+	buckets    []uint16
+	bucketSize int
+	// mask is the number of buckets - 1, the number of buckets is a power
+	// of two.
+	mask     uint64
+	maxKicks int
+	count    int
+	// victim holds the fingerprint that was kicked out when an Add failed,
+	// so that no key that was added is lost.
+	victim       uint16
+	victimBucket uint64
+	hasher       Hasher
+	seed         uint64
+	random       *rand.Rand
+}
+
+// NewCuckooFilter returns a new cuckoo filter that can hold at least
+// capacity keys.
+//
+// bucketSize is the number of fingerprints of a bucket (4 by default) and
+// maxKicks the number of fingerprints moved before an Add fails (500 by
+// default), they are used when they are not positive.
+func NewCuckooFilter(capacity, bucketSize, maxKicks int, options ...HashTableOption) *CuckooFilter {
+	if bucketSize < 1 {
+		bucketSize = defaultCuckooBucketSize
+	}
+	if maxKicks < 1 {
+		maxKicks = defaultCuckooMaxKicks
+	}
+	bucketsCount := 1
+	for float64(bucketsCount*bucketSize)*cuckooFilterMaxLoad(bucketSize) < float64(capacity) {
+		bucketsCount *= 2
+	}
+	config := newHashTableConfig(0, 0, options)
+	return &CuckooFilter{
+		buckets:    make([]uint16, bucketsCount*bucketSize),
+		bucketSize: bucketSize,
+		mask:       uint64(bucketsCount - 1),
+		maxKicks:   maxKicks,
+		hasher:     config.hasher,
+		seed:       config.seed,
+		random:     rand.New(rand.NewSource(int64(config.seed))),
+	}
+}
+
+// Add adds key to the cuckoo filter.
+//
+// it returns ErrFilterFull if the filter is full, the filter does not grow
+// because the keys are not stored.
+func (c *CuckooFilter) Add(key interface{}) error {
+	fingerprint, first, err := c.fingerprint(key)
+	if err != nil {
+		return err
+	}
+	if c.victim != 0 {
+		return ErrFilterFull
+	}
+	second := c.alternate(first, fingerprint)
+	if c.insert(first, fingerprint) || c.insert(second, fingerprint) {
+		c.count++
+		return nil
+	}
+	bucket := first
+	if c.random.Intn(2) == 1 {
+		bucket = second
+	}
+	for kick := 0; kick < c.maxKicks; kick++ {
+		slot := int(bucket)*c.bucketSize + c.random.Intn(c.bucketSize)
+		fingerprint, c.buckets[slot] = c.buckets[slot], fingerprint
+		bucket = c.alternate(bucket, fingerprint)
+		if c.insert(bucket, fingerprint) {
+			c.count++
+			return nil
+		}
+	}
+	// the key is added but the kicked out fingerprint has no slot.
+	c.victim = fingerprint
+	c.victimBucket = bucket
+	c.count++
+	return nil
+}
+
+// MayContain returns true if key may have been added to the cuckoo filter,
+// and false if it was definitely not added.
+func (c *CuckooFilter) MayContain(key interface{}) bool {
+	fingerprint, first, err := c.fingerprint(key)
+	if err != nil {
+		return false
+	}
+	second := c.alternate(first, fingerprint)
+	if c.victim == fingerprint && (c.victimBucket == first || c.victimBucket == second) {
+		return true
+	}
+	return c.find(first, fingerprint) >= 0 || c.find(second, fingerprint) >= 0
+}
+
+// Delete removes key from the cuckoo filter.
+//
+// it returns false if the key is definitely not in the filter. deleting a
+// key that was not added can delete another key.
+func (c *CuckooFilter) Delete(key interface{}) bool {
+	fingerprint, first, err := c.fingerprint(key)
+	if err != nil {
+		return false
+	}
+	second := c.alternate(first, fingerprint)
+	if c.victim == fingerprint && (c.victimBucket == first || c.victimBucket == second) {
+		c.victim = 0
+		c.count--
+		return true
+	}
+	for _, bucket := range []uint64{first, second} {
+		if slot := c.find(bucket, fingerprint); slot >= 0 {
+			c.buckets[slot] = 0
+			c.count--
+			// the slot that was freed may fit the victim.
+			if c.victim != 0 {
+				victim, victimBucket := c.victim, c.victimBucket
+				c.victim = 0
+				if !c.insert(victimBucket, victim) && !c.insert(c.alternate(victimBucket, victim), victim) {
+					c.victim = victim
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// Count returns the number of keys in the cuckoo filter.
+func (c *CuckooFilter) Count() int {
+	return c.count
+}
+
+// Capacity returns the number of fingerprints the cuckoo filter can hold.
+func (c *CuckooFilter) Capacity() int {
+	return len(c.buckets)
+}
+
+// LoadFactor returns the ratio of used fingerprint slots.
+func (c *CuckooFilter) LoadFactor() float64 {
+	return float64(c.count) / float64(len(c.buckets))
+}
+
+// fingerprint is a helper method that returns the fingerprint of key and
+// its first bucket.
+func (c *CuckooFilter) fingerprint(key interface{}) (uint16, uint64, error) {
+	hash, err := c.hasher.Hash(key, c.seed)
+	if err != nil {
+		return 0, 0, err
+	}
+	fingerprint := uint16(hash >> 48)
+	// 0 marks an empty slot.
+	if fingerprint == 0 {
+		fingerprint = 1
+	}
+	return fingerprint, hash & c.mask, nil
+}
+
+// alternate is a helper method that returns the other bucket of a
+// fingerprint, it only depends on the fingerprint so that it can be found
+// without the key (partial-key cuckoo hashing).
+func (c *CuckooFilter) alternate(bucket uint64, fingerprint uint16) uint64 {
+	return (bucket ^ mixHash(uint64(fingerprint))) & c.mask
+}
+
+// insert is a helper method that stores fingerprint in a free slot of
+// bucket, it returns false if the bucket is full.
+func (c *CuckooFilter) insert(bucket uint64, fingerprint uint16) bool {
+	start := int(bucket) * c.bucketSize
+	for slot := start; slot < start+c.bucketSize; slot++ {
+		if c.buckets[slot] == 0 {
+			c.buckets[slot] = fingerprint
+			return true
+		}
+	}
+	return false
+}
+
+// find is a helper method that returns the slot of fingerprint in bucket
+// or -1 if the bucket does not contain it.
+func (c *CuckooFilter) find(bucket uint64, fingerprint uint16) int {
+	start := int(bucket) * c.bucketSize
+	for slot := start; slot < start+c.bucketSize; slot++ {
+		if c.buckets[slot] == fingerprint {
+			return slot
+		}
+	}
+	return -1
+}
+
+// cuckooFilterMaxLoad is a helper function that returns the load factor a
+// cuckoo filter with bucketSize fingerprints per bucket usually reaches
+// before an Add fails.
+func cuckooFilterMaxLoad(bucketSize int) float64 {
+	switch {
+	case bucketSize == 1:
+		return 0.5
+	case bucketSize == 2:
+		return 0.84
+	case bucketSize < 8:
+		return 0.9
+	default:
+		return 0.95
+	}
+}
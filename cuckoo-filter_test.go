@@ -0,0 +1,137 @@
+package datastructures
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestCuckooFilter_AddMayContain(t *testing.T) {
+	c := NewCuckooFilter(1000, 4, 500)
+	for i := 0; i < 1000; i++ {
+		if err := c.Add("item " + strconv.Itoa(i)); err != nil {
+			t.Fatalf("CuckooFilter.Add(%v) error = %v", i, err)
+		}
+	}
+	if c.Count() != 1000 {
+		t.Errorf("CuckooFilter.Count() = %v, want %v", c.Count(), 1000)
+	}
+	for i := 0; i < 1000; i++ {
+		if !c.MayContain("item " + strconv.Itoa(i)) {
+			t.Fatalf("CuckooFilter.MayContain(%v) = false, want true", i)
+		}
+	}
+	falsePositives := 0
+	for i := 0; i < 10000; i++ {
+		if c.MayContain("other " + strconv.Itoa(i)) {
+			falsePositives++
+		}
+	}
+	// 8 slots checked with 16 bit fingerprints gives about 1 false
+	// positive per 8192 lookups.
+	if falsePositives > 10 {
+		t.Errorf("CuckooFilter false positives = %v, want about %v", falsePositives, 1)
+	}
+	if err := c.Add([]int{1}); !errors.Is(err, ErrUnsupportedKey) {
+		t.Errorf("CuckooFilter.Add() error = %v, wantErr %v", err, ErrUnsupportedKey)
+	}
+}
+
+func TestCuckooFilter_Delete(t *testing.T) {
+	tests := []struct {
+		name       string
+		add        []int
+		delete     []int
+		want       []bool
+		wantAfter  []int
+		wantAbsent []int
+	}{
+		{
+			name:       "existing keys",
+			add:        []int{1, 2, 3},
+			delete:     []int{1, 3},
+			want:       []bool{true, true},
+			wantAfter:  []int{2},
+			wantAbsent: []int{1, 3},
+		},
+		{
+			name:      "missing key",
+			add:       []int{1},
+			delete:    []int{4},
+			want:      []bool{false},
+			wantAfter: []int{1},
+		},
+		{
+			name:      "duplicate keys",
+			add:       []int{5, 5},
+			delete:    []int{5},
+			want:      []bool{true},
+			wantAfter: []int{5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCuckooFilter(100, 0, 0)
+			for _, key := range tt.add {
+				c.Add(key)
+			}
+			for i, key := range tt.delete {
+				if got := c.Delete(key); got != tt.want[i] {
+					t.Errorf("CuckooFilter.Delete(%v) = %v, want %v", key, got, tt.want[i])
+				}
+			}
+			for _, key := range tt.wantAfter {
+				if !c.MayContain(key) {
+					t.Errorf("CuckooFilter.MayContain(%v) = false, want true", key)
+				}
+			}
+			for _, key := range tt.wantAbsent {
+				if c.MayContain(key) {
+					t.Errorf("CuckooFilter.MayContain(%v) = true, want false", key)
+				}
+			}
+		})
+	}
+}
+
+func TestCuckooFilter_full(t *testing.T) {
+	c := NewCuckooFilter(8, 2, 5, HashTableSeed(4))
+	added := []int{}
+	var err error
+	for i := 0; i < 100; i++ {
+		if err = c.Add(i); err != nil {
+			break
+		}
+		added = append(added, i)
+	}
+	if !errors.Is(err, ErrFilterFull) {
+		t.Fatalf("CuckooFilter.Add() error = %v, wantErr %v", err, ErrFilterFull)
+	}
+	// the keys added before the filter was full are not lost.
+	for _, key := range added {
+		if !c.MayContain(key) {
+			t.Errorf("CuckooFilter.MayContain(%v) = false, want true", key)
+		}
+	}
+	if c.Count() != len(added) {
+		t.Errorf("CuckooFilter.Count() = %v, want %v", c.Count(), len(added))
+	}
+	// deleting keys frees slots for the kicked out fingerprint and for new
+	// keys.
+	deleted := 0
+	for c.victim != 0 && deleted < len(added) {
+		c.Delete(added[deleted])
+		deleted++
+	}
+	if c.victim != 0 {
+		t.Fatalf("CuckooFilter victim was not reinserted")
+	}
+	if err := c.Add(1000); err != nil {
+		t.Errorf("CuckooFilter.Add() error = %v after deletes", err)
+	}
+	for _, key := range added[deleted:] {
+		if !c.MayContain(key) {
+			t.Errorf("CuckooFilter.MayContain(%v) = false, want true", key)
+		}
+	}
+}
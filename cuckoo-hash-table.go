@@ -0,0 +1,255 @@
+package datastructures
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+const (
+	cuckooMaxLoadFactor = 0.9
+	// cuckooMinGrowLoadFactor is the load factor below which a failed
+	// insert does not grow the table, the entry goes to the stash instead.
+	// it stops keys whose hashes collide completely from growing the table
+	// forever.
+	cuckooMinGrowLoadFactor = 0.25
+)
+
+type cuckooSlot struct {
+	key      interface{}
+	value    interface{}
+	hash     uint64
+	occupied bool
+}
+
+// CuckooHashTable represents a hash table data structure that uses cuckoo
+// hashing.
+//
+// every key can only be stored in one of two buckets, so a lookup checks at
+// most two buckets (worst case O(1)). an insert that finds both buckets full
+// kicks an entry to its other bucket, and the table grows and rehashes when
+// an insert still fails after max kicks.
+type CuckooHashTable struct {
+	slots      []cuckooSlot
+	bucketSize int
+	// mask is the number of buckets - 1, the number of buckets is a power
+	// of two.
+	mask     uint64
+	maxKicks int
+	// stash holds the entries that could not be placed in a table with a
+	// low load factor, it is empty with a good hasher.
+	stash         []cuckooSlot
+	elementsCount int
+	maxLoadFactor float64
+	hasher        Hasher
+	seed          uint64
+	random        *rand.Rand
+}
+
+// NewCuckooHashTable returns a new cuckoo hash table that can hold at
+// least size elements.
+//
+// bucketSize is the number of entries of a bucket (4 by default) and
+// maxKicks the number of entries moved before the table grows (500 by
+// default), they are used when they are not positive.
+func NewCuckooHashTable(size, bucketSize, maxKicks int, options ...HashTableOption) *CuckooHashTable {
+	if bucketSize < 1 {
+		bucketSize = defaultCuckooBucketSize
+	}
+	if maxKicks < 1 {
+		maxKicks = defaultCuckooMaxKicks
+	}
+	bucketsCount := 1
+	for bucketsCount*bucketSize < size {
+		bucketsCount *= 2
+	}
+	config := newHashTableConfig(0, cuckooMaxLoadFactor, options)
+	return &CuckooHashTable{
+		slots:         make([]cuckooSlot, bucketsCount*bucketSize),
+		bucketSize:    bucketSize,
+		mask:          uint64(bucketsCount - 1),
+		maxKicks:      maxKicks,
+		maxLoadFactor: openAddressingLoadFactor(config.maxLoadFactor, cuckooMaxLoadFactor),
+		hasher:        config.hasher,
+		seed:          config.seed,
+		random:        rand.New(rand.NewSource(int64(config.seed))),
+	}
+}
+
+// Set sets a new <Key, Value> item in the hash table.
+func (h *CuckooHashTable) Set(key interface{}, value interface{}) error {
+	hash, err := openAddressingHash(h.hasher, key, h.seed)
+	if err != nil {
+		return err
+	}
+	if slot := h.find(hash, key); slot != nil {
+		slot.value = value
+		return nil
+	}
+	if float64(h.elementsCount+1) > h.maxLoadFactor*float64(len(h.slots)) {
+		h.resize(len(h.slots) / h.bucketSize * 2)
+	}
+	h.place(cuckooSlot{key: key, value: value, hash: hash, occupied: true})
+	h.elementsCount++
+	return nil
+}
+
+// Get retrieves an item from the hash table using the key.
+func (h *CuckooHashTable) Get(key interface{}) (interface{}, error) {
+	hash, err := openAddressingHash(h.hasher, key, h.seed)
+	if err != nil {
+		return nil, err
+	}
+	slot := h.find(hash, key)
+	if slot == nil {
+		return nil, fmt.Errorf("%w: %v", ErrKeyNotFound, key)
+	}
+	return slot.value, nil
+}
+
+// Delete removes an item from the hash table in key position.
+//
+// if there is no item at key position, delete does nothing.
+func (h *CuckooHashTable) Delete(key interface{}) {
+	hash, err := openAddressingHash(h.hasher, key, h.seed)
+	if err != nil {
+		return
+	}
+	slot := h.find(hash, key)
+	if slot == nil {
+		return
+	}
+	*slot = cuckooSlot{}
+	h.elementsCount--
+	for i := range h.stash {
+		if !h.stash[i].occupied {
+			h.stash = append(h.stash[:i], h.stash[i+1:]...)
+			break
+		}
+	}
+}
+
+// Size returns the number of slots in the hash table.
+func (h *CuckooHashTable) Size() int {
+	return len(h.slots)
+}
+
+// Elements returns the number of elements in the hash table.
+func (h *CuckooHashTable) Elements() int {
+	return h.elementsCount
+}
+
+// Iterate iterates through the hash table and executes the callback function
+// f for each iteration.
+func (h *CuckooHashTable) Iterate(f func(key, value interface{})) {
+	for _, slot := range h.slots {
+		if slot.occupied {
+			f(slot.key, slot.value)
+		}
+	}
+	for _, slot := range h.stash {
+		f(slot.key, slot.value)
+	}
+}
+
+// buckets is a helper method that returns the two buckets of hash.
+func (h *CuckooHashTable) buckets(hash uint64) (uint64, uint64) {
+	first := hash & h.mask
+	second := mixHash(hash^0x9e3779b97f4a7c15) & h.mask
+	return first, second
+}
+
+// find is a helper method that returns the slot of key or nil if the key
+// is not in the hash table.
+func (h *CuckooHashTable) find(hash uint64, key interface{}) *cuckooSlot {
+	first, second := h.buckets(hash)
+	for _, bucket := range []uint64{first, second} {
+		start := int(bucket) * h.bucketSize
+		for i := start; i < start+h.bucketSize; i++ {
+			slot := &h.slots[i]
+			if slot.occupied && slot.hash == hash && h.hasher.Equal(slot.key, key) {
+				return slot
+			}
+		}
+	}
+	for i := range h.stash {
+		if h.stash[i].hash == hash && h.hasher.Equal(h.stash[i].key, key) {
+			return &h.stash[i]
+		}
+	}
+	return nil
+}
+
+// insert is a helper method that stores entry in one of its buckets,
+// kicking entries to their other bucket when both are full.
+//
+// it returns the entry that is left without a slot after max kicks and
+// false, or true if every entry has a slot.
+func (h *CuckooHashTable) insert(entry cuckooSlot) (cuckooSlot, bool) {
+	first, second := h.buckets(entry.hash)
+	if h.insertInBucket(first, entry) || h.insertInBucket(second, entry) {
+		return cuckooSlot{}, true
+	}
+	bucket := first
+	if h.random.Intn(2) == 1 {
+		bucket = second
+	}
+	for kick := 0; kick < h.maxKicks; kick++ {
+		index := int(bucket)*h.bucketSize + h.random.Intn(h.bucketSize)
+		entry, h.slots[index] = h.slots[index], entry
+		first, second := h.buckets(entry.hash)
+		bucket = first
+		if bucket == uint64(index/h.bucketSize) {
+			bucket = second
+		}
+		if h.insertInBucket(bucket, entry) {
+			return cuckooSlot{}, true
+		}
+	}
+	return entry, false
+}
+
+// place is a helper method that stores entry in the hash table, growing
+// the table until every entry has a slot.
+func (h *CuckooHashTable) place(entry cuckooSlot) {
+	for {
+		homeless, ok := h.insert(entry)
+		if ok {
+			return
+		}
+		if float64(h.elementsCount) < cuckooMinGrowLoadFactor*float64(len(h.slots)) {
+			h.stash = append(h.stash, homeless)
+			return
+		}
+		h.resize(len(h.slots) / h.bucketSize * 2)
+		entry = homeless
+	}
+}
+
+// insertInBucket is a helper method that stores entry in a free slot of
+// bucket, it returns false if the bucket is full.
+func (h *CuckooHashTable) insertInBucket(bucket uint64, entry cuckooSlot) bool {
+	start := int(bucket) * h.bucketSize
+	for i := start; i < start+h.bucketSize; i++ {
+		if !h.slots[i].occupied {
+			h.slots[i] = entry
+			return true
+		}
+	}
+	return false
+}
+
+// resize is a helper method that moves every element to a new table with
+// bucketsCount buckets.
+func (h *CuckooHashTable) resize(bucketsCount int) {
+	oldSlots, oldStash := h.slots, h.stash
+	h.slots = make([]cuckooSlot, bucketsCount*h.bucketSize)
+	h.mask = uint64(bucketsCount - 1)
+	h.stash = nil
+	for _, slots := range [][]cuckooSlot{oldSlots, oldStash} {
+		for _, slot := range slots {
+			if slot.occupied {
+				h.place(slot)
+			}
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package datastructures
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestNewCuckooHashTable(t *testing.T) {
+	tests := []struct {
+		name           string
+		size           int
+		bucketSize     int
+		maxKicks       int
+		wantSize       int
+		wantBucketSize int
+		wantMaxKicks   int
+	}{
+		{name: "default settings", size: 16, wantSize: 16, wantBucketSize: 4, wantMaxKicks: 500},
+		{name: "rounded size", size: 20, bucketSize: 2, maxKicks: 10, wantSize: 32, wantBucketSize: 2, wantMaxKicks: 10},
+		{name: "single slot buckets", size: 3, bucketSize: 1, wantSize: 4, wantBucketSize: 1, wantMaxKicks: 500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewCuckooHashTable(tt.size, tt.bucketSize, tt.maxKicks)
+			if h.Size() != tt.wantSize || h.bucketSize != tt.wantBucketSize || h.maxKicks != tt.wantMaxKicks {
+				t.Errorf("NewCuckooHashTable() size, bucket size, max kicks = %v, %v, %v, want %v, %v, %v",
+					h.Size(), h.bucketSize, h.maxKicks, tt.wantSize, tt.wantBucketSize, tt.wantMaxKicks)
+			}
+		})
+	}
+}
+
+func TestCuckooHashTable_grow(t *testing.T) {
+	tests := []struct {
+		name       string
+		bucketSize int
+		maxKicks   int
+	}{
+		{name: "single slot buckets", bucketSize: 1, maxKicks: 20},
+		{name: "four slot buckets", bucketSize: 4, maxKicks: 20},
+		{name: "no kicks", bucketSize: 2, maxKicks: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewCuckooHashTable(4, tt.bucketSize, tt.maxKicks, HashTableSeed(1))
+			for i := 0; i < 2000; i++ {
+				h.Set(i, i)
+			}
+			if h.Elements() != 2000 {
+				t.Fatalf("CuckooHashTable.Elements() = %v, want %v", h.Elements(), 2000)
+			}
+			for i := 0; i < 2000; i++ {
+				if got, err := h.Get(i); err != nil || got != i {
+					t.Fatalf("CuckooHashTable.Get(%v) = %v, %v, want %v", i, got, err, i)
+				}
+			}
+			if len(h.stash) != 0 {
+				t.Errorf("CuckooHashTable stash has %v entries, want %v", len(h.stash), 0)
+			}
+			// every key is in one of its two buckets.
+			for index, slot := range h.slots {
+				if !slot.occupied {
+					continue
+				}
+				first, second := h.buckets(slot.hash)
+				bucket := uint64(index / h.bucketSize)
+				if bucket != first && bucket != second {
+					t.Fatalf("key %v is in bucket %v, want %v or %v", slot.key, bucket, first, second)
+				}
+			}
+		})
+	}
+}
+
+func TestCuckooHashTable_collidingKeys(t *testing.T) {
+	h := NewCuckooHashTable(16, 2, 10, HashTableHasher(constantHasher{}))
+	for i := 0; i < 10; i++ {
+		h.Set(i, i)
+	}
+	// only 4 keys fit in the two buckets of the hash, the others go to the
+	// stash instead of growing the table forever.
+	if len(h.stash) != 6 {
+		t.Errorf("CuckooHashTable stash has %v entries, want %v", len(h.stash), 6)
+	}
+	for i := 0; i < 10; i++ {
+		if got, err := h.Get(i); err != nil || got != i {
+			t.Errorf("CuckooHashTable.Get(%v) = %v, %v, want %v", i, got, err, i)
+		}
+	}
+	keys := []int{}
+	for i := 0; i < 10; i += 2 {
+		h.Delete(i)
+	}
+	h.Iterate(func(key, value interface{}) {
+		keys = append(keys, key.(int))
+	})
+	sort.Ints(keys)
+	want := []int{1, 3, 5, 7, 9}
+	if len(keys) != len(want) || h.Elements() != len(want) {
+		t.Fatalf("CuckooHashTable keys = %v, want %v", keys, want)
+	}
+	for i := range want {
+		if keys[i] != want[i] {
+			t.Errorf("CuckooHashTable keys = %v, want %v", keys, want)
+		}
+	}
+}
@@ -0,0 +1,291 @@
+package datastructures
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Clock tells the time and creates timers, it lets a DelayQueue run on a
+// clock other than the system clock.
+type Clock interface {
+	Now() time.Time
+	// NewTimer returns a timer that sends the time on its channel once d
+	// has elapsed.
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a single event created by a Clock.
+type Timer interface {
+	C() <-chan time.Time
+	// Stop prevents the timer from firing, it returns false if the timer
+	// already fired or was stopped.
+	Stop() bool
+}
+
+// systemClock is the Clock of the time package.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+type systemTimer struct {
+	timer *time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time {
+	return t.timer.C
+}
+
+func (t systemTimer) Stop() bool {
+	return t.timer.Stop()
+}
+
+// ManualClock is a Clock that only moves when it is advanced, it makes code
+// that waits on a DelayQueue deterministic in tests.
+type ManualClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*manualTimer
+}
+
+// NewManualClock returns a new manual clock set to now.
+func NewManualClock(now time.Time) *ManualClock {
+	return &ManualClock{now: now}
+}
+
+// Now returns the time of the clock.
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// NewTimer returns a timer that fires once the clock is advanced by d.
+func (c *ManualClock) NewTimer(d time.Duration) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	timer := &manualTimer{clock: c, at: c.now.Add(d), c: make(chan time.Time, 1)}
+	if d <= 0 {
+		timer.c <- c.now
+		return timer
+	}
+	c.timers = append(c.timers, timer)
+	return timer
+}
+
+// Advance moves the clock forward by d and fires the timers that are due.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	pending := c.timers[:0]
+	for _, timer := range c.timers {
+		if timer.at.After(c.now) {
+			pending = append(pending, timer)
+			continue
+		}
+		timer.c <- c.now
+	}
+	for i := len(pending); i < len(c.timers); i++ {
+		c.timers[i] = nil
+	}
+	c.timers = pending
+}
+
+type manualTimer struct {
+	clock *ManualClock
+	at    time.Time
+	c     chan time.Time
+}
+
+func (t *manualTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *manualTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	for i, timer := range t.clock.timers {
+		if timer == t {
+			t.clock.timers = append(t.clock.timers[:i], t.clock.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// delayQueueConfig holds the settings of a delay queue.
+type delayQueueConfig struct {
+	clock Clock
+}
+
+// DelayQueueOption configures a delay queue created with NewDelayQueue.
+type DelayQueueOption func(config *delayQueueConfig)
+
+// DelayQueueClock sets the clock of the queue, the system clock by default.
+func DelayQueueClock(clock Clock) DelayQueueOption {
+	return func(config *delayQueueConfig) {
+		if clock != nil {
+			config.clock = clock
+		}
+	}
+}
+
+// DelayQueueHandle is an item scheduled in a delay queue, it is returned by
+// Schedule and used to cancel or reschedule the item.
+type DelayQueueHandle[T any] struct {
+	item T
+	at   time.Time
+	// sequence is the order in which the item was scheduled.
+	sequence   uint64
+	heapHandle HeapHandle[*DelayQueueHandle[T]]
+}
+
+// Item returns the item of the handle.
+func (handle *DelayQueueHandle[T]) Item() T {
+	return handle.item
+}
+
+// before orders the handles by deadline, and by sequence for equal
+// deadlines.
+func (handle *DelayQueueHandle[T]) before(other *DelayQueueHandle[T]) bool {
+	if !handle.at.Equal(other.at) {
+		return handle.at.Before(other.at)
+	}
+	return handle.sequence < other.sequence
+}
+
+// DelayQueue represents a delay queue data structure that is safe for
+// concurrent use, an item can only be taken once its deadline is reached.
+//
+// the items are kept in a heap ordered by deadline, items with the same
+// deadline are taken in the order they were scheduled.
+type DelayQueue[T any] struct {
+	mu       sync.Mutex
+	heap     *Heap[*DelayQueueHandle[T]]
+	sequence uint64
+	clock    Clock
+	// changed is closed and replaced whenever the earliest deadline may
+	// have changed, to wake up every waiting Take.
+	changed chan struct{}
+}
+
+// NewDelayQueue returns a new delay queue data structure.
+func NewDelayQueue[T any](options ...DelayQueueOption) *DelayQueue[T] {
+	config := delayQueueConfig{clock: systemClock{}}
+	for _, option := range options {
+		option(&config)
+	}
+	return &DelayQueue[T]{
+		heap:    NewHeap((*DelayQueueHandle[T]).before),
+		clock:   config.clock,
+		changed: make(chan struct{}),
+	}
+}
+
+// Schedule adds an item that becomes available at the deadline at and
+// returns its handle.
+func (q *DelayQueue[T]) Schedule(item T, at time.Time) *DelayQueueHandle[T] {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	handle := &DelayQueueHandle[T]{item: item, at: at}
+	q.push(handle)
+	q.notify()
+	return handle
+}
+
+// Cancel removes the item of handle from the queue before it is taken.
+func (q *DelayQueue[T]) Cancel(handle *DelayQueueHandle[T]) error {
+	if handle == nil {
+		return ErrInvalidHandle
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if err := q.heap.Delete(handle.heapHandle); err != nil {
+		return err
+	}
+	q.notify()
+	return nil
+}
+
+// Reschedule moves the deadline of the item of handle to at.
+func (q *DelayQueue[T]) Reschedule(handle *DelayQueueHandle[T], at time.Time) error {
+	if handle == nil {
+		return ErrInvalidHandle
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if err := q.heap.Delete(handle.heapHandle); err != nil {
+		return err
+	}
+	handle.at = at
+	q.push(handle)
+	q.notify()
+	return nil
+}
+
+// Take removes the item with the earliest deadline, waiting until the
+// deadline is reached. it returns the error of ctx if ctx is done first.
+func (q *DelayQueue[T]) Take(ctx context.Context) (T, error) {
+	for {
+		q.mu.Lock()
+		changed := q.changed
+		var timer Timer
+		if handle, err := q.heap.Min(); err == nil {
+			wait := handle.at.Sub(q.clock.Now())
+			if wait <= 0 {
+				q.heap.ExtractMin()
+				q.mu.Unlock()
+				return handle.item, nil
+			}
+			timer = q.clock.NewTimer(wait)
+		}
+		q.mu.Unlock()
+
+		// a nil channel blocks, so an empty queue waits for a change.
+		var expired <-chan time.Time
+		if timer != nil {
+			expired = timer.C()
+		}
+		select {
+		case <-ctx.Done():
+			if timer != nil {
+				timer.Stop()
+			}
+			var zero T
+			return zero, ctx.Err()
+		case <-changed:
+			if timer != nil {
+				timer.Stop()
+			}
+		case <-expired:
+		}
+	}
+}
+
+// Size returns the number of items in the queue, including the ones whose
+// deadline is not reached.
+func (q *DelayQueue[T]) Size() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.heap.Size()
+}
+
+// push adds handle to the heap with the next sequence.
+func (q *DelayQueue[T]) push(handle *DelayQueueHandle[T]) {
+	handle.sequence = q.sequence
+	q.sequence++
+	handle.heapHandle = q.heap.Push(handle)
+}
+
+// notify wakes up every waiting Take.
+func (q *DelayQueue[T]) notify() {
+	close(q.changed)
+	q.changed = make(chan struct{})
+}
@@ -0,0 +1,229 @@
+package datastructures
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// takeDue is a test helper that takes the items that are due without
+// waiting, using a context that is already done.
+func takeDue[T any](q *DelayQueue[T]) []T {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	items := []T{}
+	for {
+		item, err := q.Take(ctx)
+		if err != nil {
+			return items
+		}
+		items = append(items, item)
+	}
+}
+
+func TestDelayQueue_Take(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		items    []string
+		delays   []time.Duration
+		advances []time.Duration
+		want     [][]string
+	}{
+		{
+			name:     "items become available at their deadlines",
+			items:    []string{"c", "a", "b"},
+			delays:   []time.Duration{3 * time.Second, time.Second, 2 * time.Second},
+			advances: []time.Duration{0, time.Second, 1500 * time.Millisecond, time.Hour},
+			want:     [][]string{{}, {"a"}, {"b"}, {"c"}},
+		},
+		{
+			name:     "equal deadlines are taken in scheduling order",
+			items:    []string{"a", "b", "c", "d"},
+			delays:   []time.Duration{time.Second, time.Second, 0, time.Second},
+			advances: []time.Duration{0, time.Second},
+			want:     [][]string{{"c"}, {"a", "b", "d"}},
+		},
+		{
+			name:     "past deadlines are available at once",
+			items:    []string{"a", "b"},
+			delays:   []time.Duration{-time.Minute, -time.Hour},
+			advances: []time.Duration{0},
+			want:     [][]string{{"b", "a"}},
+		},
+		{
+			name:     "distant deadlines are ordered to the nanosecond",
+			items:    []string{"b", "a"},
+			delays:   []time.Duration{200*24*time.Hour + 1, 200 * 24 * time.Hour},
+			advances: []time.Duration{200 * 24 * time.Hour, 1},
+			want:     [][]string{{"a"}, {"b"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := NewManualClock(start)
+			q := NewDelayQueue[string](DelayQueueClock(clock))
+			for i, item := range tt.items {
+				q.Schedule(item, start.Add(tt.delays[i]))
+			}
+			for i, advance := range tt.advances {
+				clock.Advance(advance)
+				if got := takeDue(q); !reflect.DeepEqual(got, tt.want[i]) {
+					t.Errorf("DelayQueue.Take() after %v = %v, want %v", clock.Now().Sub(start), got, tt.want[i])
+				}
+			}
+			if q.Size() != 0 {
+				t.Errorf("DelayQueue.Size() = %v, want %v", q.Size(), 0)
+			}
+		})
+	}
+}
+
+func TestDelayQueue_Take_blocking(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewManualClock(start)
+	q := NewDelayQueue[int](DelayQueueClock(clock))
+	q.Schedule(1, start.Add(time.Minute))
+	results := make(chan int)
+	for i := 0; i < 2; i++ {
+		go func() {
+			item, err := q.Take(context.Background())
+			if err != nil {
+				t.Errorf("DelayQueue.Take() error = %v", err)
+			}
+			results <- item
+		}()
+	}
+	// an earlier item wakes up a waiting Take, the other one keeps
+	// waiting for the clock.
+	q.Schedule(2, start.Add(-time.Second))
+	if got := <-results; got != 2 {
+		t.Errorf("DelayQueue.Take() = %v, want %v", got, 2)
+	}
+	clock.Advance(time.Minute)
+	if got := <-results; got != 1 {
+		t.Errorf("DelayQueue.Take() = %v, want %v", got, 1)
+	}
+}
+
+func TestDelayQueue_Take_canceled(t *testing.T) {
+	clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	q := NewDelayQueue[int](DelayQueueClock(clock))
+	q.Schedule(1, clock.Now().Add(time.Hour))
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error)
+	go func() {
+		_, err := q.Take(ctx)
+		errs <- err
+	}()
+	cancel()
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Errorf("DelayQueue.Take() error = %v, wantErr %v", err, context.Canceled)
+	}
+	if q.Size() != 1 {
+		t.Errorf("DelayQueue.Size() = %v, want %v", q.Size(), 1)
+	}
+}
+
+func TestDelayQueue_Cancel(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewManualClock(start)
+	q := NewDelayQueue[string](DelayQueueClock(clock))
+	a := q.Schedule("a", start.Add(time.Second))
+	b := q.Schedule("b", start.Add(2*time.Second))
+	taken := q.Schedule("taken", start)
+	takeDue(q)
+	tests := []struct {
+		name    string
+		queue   *DelayQueue[string]
+		handle  *DelayQueueHandle[string]
+		wantErr error
+	}{
+		{name: "scheduled item", queue: q, handle: a},
+		{name: "canceled item", queue: q, handle: a, wantErr: ErrInvalidHandle},
+		{name: "taken item", queue: q, handle: taken, wantErr: ErrInvalidHandle},
+		{name: "item of another queue", queue: NewDelayQueue[string](), handle: b, wantErr: ErrInvalidHandle},
+		{name: "nil handle", queue: q, wantErr: ErrInvalidHandle},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.queue.Cancel(tt.handle); !errors.Is(err, tt.wantErr) {
+				t.Errorf("DelayQueue.Cancel() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+	clock.Advance(time.Hour)
+	if got, want := takeDue(q), []string{"b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DelayQueue.Take() = %v, want %v", got, want)
+	}
+}
+
+func TestDelayQueue_Reschedule(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewManualClock(start)
+	q := NewDelayQueue[string](DelayQueueClock(clock))
+	a := q.Schedule("a", start.Add(time.Second))
+	b := q.Schedule("b", start.Add(time.Hour))
+	q.Schedule("c", start.Add(time.Minute))
+	if err := q.Reschedule(a, start.Add(time.Minute)); err != nil {
+		t.Fatalf("DelayQueue.Reschedule() error = %v", err)
+	}
+	if err := q.Reschedule(b, start.Add(time.Second)); err != nil {
+		t.Fatalf("DelayQueue.Reschedule() error = %v", err)
+	}
+	clock.Advance(time.Second)
+	if got, want := takeDue(q), []string{"b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DelayQueue.Take() = %v, want %v", got, want)
+	}
+	// a is due with c, after it because it was rescheduled later.
+	clock.Advance(time.Minute)
+	if got, want := takeDue(q), []string{"c", "a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DelayQueue.Take() = %v, want %v", got, want)
+	}
+	if err := q.Reschedule(a, start); !errors.Is(err, ErrInvalidHandle) {
+		t.Errorf("DelayQueue.Reschedule() of a taken item error = %v, wantErr %v", err, ErrInvalidHandle)
+	}
+}
+
+func TestDelayQueue_systemClock(t *testing.T) {
+	q := NewDelayQueue[int]()
+	q.Schedule(1, time.Now().Add(10*time.Millisecond))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if got, err := q.Take(ctx); err != nil || got != 1 {
+		t.Errorf("DelayQueue.Take() = %v, %v, want %v", got, err, 1)
+	}
+}
+
+func TestManualClock_Advance(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewManualClock(start)
+	early, late, stopped := clock.NewTimer(time.Second), clock.NewTimer(time.Minute), clock.NewTimer(time.Second)
+	if !stopped.Stop() {
+		t.Errorf("Timer.Stop() = false, want true")
+	}
+	clock.Advance(time.Second)
+	select {
+	case now := <-early.C():
+		if !now.Equal(start.Add(time.Second)) {
+			t.Errorf("Timer.C() = %v, want %v", now, start.Add(time.Second))
+		}
+	default:
+		t.Errorf("Timer.C() did not fire when it was due")
+	}
+	select {
+	case <-late.C():
+		t.Errorf("Timer.C() fired before it was due")
+	case <-stopped.C():
+		t.Errorf("Timer.C() fired after it was stopped")
+	default:
+	}
+	if early.Stop() {
+		t.Errorf("Timer.Stop() of a fired timer = true, want false")
+	}
+	if now := <-clock.NewTimer(0).C(); !now.Equal(clock.Now()) {
+		t.Errorf("Timer.C() = %v, want %v", now, clock.Now())
+	}
+}
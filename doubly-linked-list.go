@@ -74,6 +74,8 @@ func (l *DoublyLinkedList) Clear() {
 		trav.Previous = nil
 		trav = next
 	}
+	l.head = nil
+	l.tail = nil
 	l.length = 0
 }
 
@@ -126,6 +128,7 @@ func (l *DoublyLinkedList) RemoveHead() *DoublyLinkedList {
 		return l
 	}
 	l.head = l.head.Next
+	l.head.Previous = nil
 	l.length--
 	return l
 }
@@ -142,6 +145,27 @@ func (l *DoublyLinkedList) RemoveTail() *DoublyLinkedList {
 		return l
 	}
 	l.tail = l.tail.Previous
+	l.tail.Next = nil
+	l.length--
+	return l
+}
+
+// Remove removes node from the doubly linked list.
+//
+// node must be a node of the list.
+func (l *DoublyLinkedList) Remove(node *DoublyLinkedListNode) *DoublyLinkedList {
+	if node == l.head {
+		l.head = node.Next
+	} else {
+		node.Previous.Next = node.Next
+	}
+	if node == l.tail {
+		l.tail = node.Previous
+	} else {
+		node.Next.Previous = node.Previous
+	}
+	node.Next = nil
+	node.Previous = nil
 	l.length--
 	return l
 }
@@ -1,6 +1,7 @@
 package datastructures
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -39,6 +40,9 @@ func TestDoublyLinkedList_Clear(t *testing.T) {
 	if list.Size() != 0 {
 		t.Errorf("Size(): expected = %v, got = %v", 0, list.Size())
 	}
+	list.Iterate(func(index int, node *DoublyLinkedListNode) {
+		t.Errorf("Iterate(): expected no nodes, got = %v", node)
+	})
 	// readding the nodes
 	list.Add(node).Add(node2)
 	if list.Size() != 2 {
@@ -173,3 +177,48 @@ func TestDoublyLinkedList_RemoveTail(t *testing.T) {
 		t.Errorf("GetTail(): expected = %v, got = %v", node, list.GetTail())
 	}
 }
+
+func TestDoublyLinkedList_Remove(t *testing.T) {
+	tests := []struct {
+		name   string
+		nodes  int
+		remove []int
+		want   []int
+	}{
+		{name: "removing the head", nodes: 3, remove: []int{0}, want: []int{1, 2}},
+		{name: "removing the tail", nodes: 3, remove: []int{2}, want: []int{0, 1}},
+		{name: "removing a middle node", nodes: 3, remove: []int{1}, want: []int{0, 2}},
+		{name: "removing every node", nodes: 2, remove: []int{1, 0}, want: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list := NewDoublyLinkedList()
+			nodes := []*DoublyLinkedListNode{}
+			for i := 0; i < tt.nodes; i++ {
+				nodes = append(nodes, &DoublyLinkedListNode{Data: i})
+				list.Add(nodes[i])
+			}
+			for _, index := range tt.remove {
+				list.Remove(nodes[index])
+			}
+			got := []int{}
+			list.Iterate(func(_ int, node *DoublyLinkedListNode) {
+				got = append(got, node.Data.(int))
+			})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Iterate(): expected = %v, got = %v", tt.want, got)
+			}
+			if list.Size() != len(tt.want) {
+				t.Errorf("Size(): expected = %v, got = %v", len(tt.want), list.Size())
+			}
+			// walking back from the tail must give the same nodes.
+			backwards := []int{}
+			for node := list.GetTail(); node != nil; node = node.Previous {
+				backwards = append([]int{node.Data.(int)}, backwards...)
+			}
+			if !reflect.DeepEqual(backwards, tt.want) {
+				t.Errorf("Previous links: expected = %v, got = %v", tt.want, backwards)
+			}
+		})
+	}
+}
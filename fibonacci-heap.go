@@ -0,0 +1,288 @@
+package datastructures
+
+import (
+	"errors"
+	"fmt"
+)
+
+// fibonacciHeapNode is a node of a fibonacci heap, it is the HeapHandle
+// returned by Insert.
+type fibonacciHeapNode[T any] struct {
+	item   T
+	parent *fibonacciHeapNode[T]
+	child  *fibonacciHeapNode[T]
+	// left and right link the node with its siblings in a circular list.
+	left  *fibonacciHeapNode[T]
+	right *fibonacciHeapNode[T]
+	// degree is the number of children.
+	degree int
+	// marked is true if the node lost a child since it became a child.
+	marked bool
+	// owner is nil once the node is removed.
+	owner *heapOwner
+}
+
+// Item returns the item of the node.
+func (node *fibonacciHeapNode[T]) Item() T {
+	return node.item
+}
+
+// FibonacciHeap represents a fibonacci heap data structure, a list of
+// heap-ordered trees that are only consolidated by ExtractMin.
+//
+// Insert, Min, Meld and DecreaseKey are O(1) amortized and ExtractMin and
+// Delete are O(log n) amortized.
+type FibonacciHeap[T any] struct {
+	// min is the root with the smallest item, the roots are in a circular
+	// list.
+	min   *fibonacciHeapNode[T]
+	size  int
+	less  func(a, b T) bool
+	owner *heapOwner
+}
+
+// NewFibonacciHeap returns a new fibonacci heap data structure ordered by
+// less.
+func NewFibonacciHeap[T any](less func(a, b T) bool) *FibonacciHeap[T] {
+	return &FibonacciHeap[T]{less: less, owner: &heapOwner{}}
+}
+
+// Insert adds a new item to the heap and returns its handle.
+func (h *FibonacciHeap[T]) Insert(item T) HeapHandle[T] {
+	node := &fibonacciHeapNode[T]{item: item, owner: h.owner}
+	node.left, node.right = node, node
+	h.addRoot(node)
+	h.size++
+	return node
+}
+
+// Push adds a new item to the heap, it is the same as Insert.
+func (h *FibonacciHeap[T]) Push(item T) HeapHandle[T] {
+	return h.Insert(item)
+}
+
+// Min returns the smallest item of the heap without removing it.
+func (h *FibonacciHeap[T]) Min() (T, error) {
+	if h.min == nil {
+		var zero T
+		return zero, errors.New("heap is empty")
+	}
+	return h.min.item, nil
+}
+
+// ExtractMin removes the smallest item from the heap.
+//
+// the children of the smallest item become roots and the roots with the
+// same degree are linked until all the roots have distinct degrees.
+func (h *FibonacciHeap[T]) ExtractMin() (T, error) {
+	if h.min == nil {
+		var zero T
+		return zero, errors.New("heap is empty")
+	}
+	min := h.min
+	for min.child != nil {
+		child := min.child
+		h.cut(child, min)
+	}
+	if min.right == min {
+		h.min = nil
+	} else {
+		h.min = min.right
+		fibonacciHeapUnlink(min)
+		h.consolidate()
+	}
+	h.size--
+	min.owner, min.left, min.right = nil, nil, nil
+	return min.item, nil
+}
+
+// DecreaseKey replaces the item of handle with item, which must not be
+// greater than the current item.
+//
+// a node whose item becomes smaller than its parent item is cut from its
+// parent, and so are the ancestors that already lost a child.
+func (h *FibonacciHeap[T]) DecreaseKey(handle HeapHandle[T], item T) error {
+	node, err := h.node(handle)
+	if err != nil {
+		return err
+	}
+	if h.less(node.item, item) {
+		return fmt.Errorf("%w: %v is greater than %v", ErrInvalidDecrease, item, node.item)
+	}
+	node.item = item
+	if parent := node.parent; parent != nil && h.less(node.item, parent.item) {
+		h.cut(node, parent)
+		h.cascadingCut(parent)
+	}
+	if h.less(node.item, h.min.item) {
+		h.min = node
+	}
+	return nil
+}
+
+// Delete removes the item of handle from the heap.
+//
+// the node is made a root and removed as if it had the smallest item.
+func (h *FibonacciHeap[T]) Delete(handle HeapHandle[T]) error {
+	node, err := h.node(handle)
+	if err != nil {
+		return err
+	}
+	if parent := node.parent; parent != nil {
+		h.cut(node, parent)
+		h.cascadingCut(parent)
+	}
+	h.min = node
+	_, err = h.ExtractMin()
+	return err
+}
+
+// Meld moves the items of other, which must be a *FibonacciHeap[T], to the
+// heap in O(1), other is emptied.
+func (h *FibonacciHeap[T]) Meld(other PriorityQueue[T]) error {
+	o, ok := other.(*FibonacciHeap[T])
+	if !ok {
+		return fmt.Errorf("%w: %T and %T", ErrIncompatibleHeap, h, other)
+	}
+	if o == h || o.min == nil {
+		return nil
+	}
+	if h.min == nil {
+		h.min = o.min
+	} else {
+		// the two circular lists are joined after h.min.
+		hRight, oLeft := h.min.right, o.min.left
+		h.min.right, o.min.left = o.min, h.min
+		oLeft.right, hRight.left = hRight, oLeft
+		if h.less(o.min.item, h.min.item) {
+			h.min = o.min
+		}
+	}
+	h.size += o.size
+	o.owner.melded = h.owner
+	*o = *NewFibonacciHeap(o.less)
+	return nil
+}
+
+// Size returns the number of items in the heap.
+func (h *FibonacciHeap[T]) Size() int {
+	return h.size
+}
+
+// node returns handle as a node of the heap.
+func (h *FibonacciHeap[T]) node(handle HeapHandle[T]) (*fibonacciHeapNode[T], error) {
+	node, ok := handle.(*fibonacciHeapNode[T])
+	if !ok || node.owner == nil || node.owner.find() != h.owner {
+		return nil, ErrInvalidHandle
+	}
+	return node, nil
+}
+
+// addRoot adds a node that is not in a list to the roots.
+func (h *FibonacciHeap[T]) addRoot(node *fibonacciHeapNode[T]) {
+	node.parent = nil
+	if h.min == nil {
+		node.left, node.right = node, node
+		h.min = node
+		return
+	}
+	fibonacciHeapInsertAfter(h.min, node)
+	if h.less(node.item, h.min.item) {
+		h.min = node
+	}
+}
+
+// cut makes node, a child of parent, a root.
+func (h *FibonacciHeap[T]) cut(node, parent *fibonacciHeapNode[T]) {
+	if node.right == node {
+		parent.child = nil
+	} else {
+		if parent.child == node {
+			parent.child = node.right
+		}
+		fibonacciHeapUnlink(node)
+	}
+	parent.degree--
+	node.marked = false
+	h.addRoot(node)
+}
+
+// cascadingCut cuts node from its parent if it already lost a child,
+// otherwise it marks node.
+func (h *FibonacciHeap[T]) cascadingCut(node *fibonacciHeapNode[T]) {
+	for node.parent != nil {
+		if !node.marked {
+			node.marked = true
+			return
+		}
+		parent := node.parent
+		h.cut(node, parent)
+		node = parent
+	}
+}
+
+// consolidate links the roots with the same degree until every root has a
+// distinct degree and finds the new smallest root.
+func (h *FibonacciHeap[T]) consolidate() {
+	roots := []*fibonacciHeapNode[T]{}
+	for node := h.min; ; {
+		roots = append(roots, node)
+		node = node.right
+		if node == h.min {
+			break
+		}
+	}
+	byDegree := []*fibonacciHeapNode[T]{}
+	for _, node := range roots {
+		for {
+			for len(byDegree) <= node.degree {
+				byDegree = append(byDegree, nil)
+			}
+			other := byDegree[node.degree]
+			if other == nil {
+				byDegree[node.degree] = node
+				break
+			}
+			byDegree[node.degree] = nil
+			if h.less(other.item, node.item) {
+				node, other = other, node
+			}
+			h.link(other, node)
+		}
+	}
+	h.min = nil
+	for _, node := range byDegree {
+		if node != nil {
+			node.left, node.right = node, node
+			h.addRoot(node)
+		}
+	}
+}
+
+// link makes the root child a child of the root parent.
+func (h *FibonacciHeap[T]) link(child, parent *fibonacciHeapNode[T]) {
+	fibonacciHeapUnlink(child)
+	child.parent = parent
+	child.marked = false
+	if parent.child == nil {
+		child.left, child.right = child, child
+		parent.child = child
+	} else {
+		fibonacciHeapInsertAfter(parent.child, child)
+	}
+	parent.degree++
+}
+
+// fibonacciHeapInsertAfter adds node after position in a circular list.
+func fibonacciHeapInsertAfter[T any](position, node *fibonacciHeapNode[T]) {
+	node.left, node.right = position, position.right
+	position.right.left = node
+	position.right = node
+}
+
+// fibonacciHeapUnlink removes node from its circular list.
+func fibonacciHeapUnlink[T any](node *fibonacciHeapNode[T]) {
+	node.left.right = node.right
+	node.right.left = node.left
+	node.left, node.right = node, node
+}
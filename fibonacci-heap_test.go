@@ -0,0 +1,102 @@
+package datastructures
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// validateFibonacciHeap is a test helper that checks the circular lists,
+// the degrees and the heap order of every node and that min is the
+// smallest root. it returns the number of nodes and the number of roots.
+func validateFibonacciHeap[T any](t *testing.T, h *FibonacciHeap[T]) (int, int) {
+	t.Helper()
+	if h.min == nil {
+		return 0, 0
+	}
+	var walk func(first *fibonacciHeapNode[T], parent *fibonacciHeapNode[T]) (int, int)
+	walk = func(first *fibonacciHeapNode[T], parent *fibonacciHeapNode[T]) (int, int) {
+		count, siblings := 0, 0
+		for node := first; ; {
+			if node.right.left != node || node.parent != parent {
+				t.Fatalf("FibonacciHeap node %v has wrong links", node.item)
+			}
+			if parent != nil && h.less(node.item, parent.item) {
+				t.Fatalf("FibonacciHeap node %v is less than its parent %v", node.item, parent.item)
+			}
+			if parent == nil && h.less(node.item, h.min.item) {
+				t.Fatalf("FibonacciHeap root %v is less than min %v", node.item, h.min.item)
+			}
+			children := 0
+			if node.child != nil {
+				var descendants int
+				descendants, children = walk(node.child, node)
+				count += descendants
+			}
+			if children != node.degree {
+				t.Fatalf("FibonacciHeap node %v has %v children, degree %v", node.item, children, node.degree)
+			}
+			count++
+			siblings++
+			node = node.right
+			if node == first {
+				return count, siblings
+			}
+		}
+	}
+	return walk(h.min, nil)
+}
+
+func TestFibonacciHeap_structure(t *testing.T) {
+	tests := []struct {
+		name      string
+		items     int
+		decreases int
+		deletes   int
+		extracts  int
+	}{
+		{name: "inserts", items: 100},
+		{name: "inserts and extracts", items: 100, extracts: 60},
+		{name: "decreases and deletes", items: 200, decreases: 150, deletes: 50, extracts: 30},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			random := rand.New(rand.NewSource(5))
+			h := NewFibonacciHeap(func(a, b int) bool { return a < b })
+			handles := []HeapHandle[int]{}
+			for i := 0; i < tt.items; i++ {
+				handles = append(handles, h.Insert(random.Intn(1000)))
+			}
+			for i := 0; i < tt.decreases; i++ {
+				handle := handles[random.Intn(len(handles))]
+				h.DecreaseKey(handle, handle.Item()-random.Intn(500))
+			}
+			random.Shuffle(len(handles), func(i, j int) { handles[i], handles[j] = handles[j], handles[i] })
+			for _, handle := range handles[:tt.deletes] {
+				h.Delete(handle)
+			}
+			for i := 0; i < tt.extracts; i++ {
+				h.ExtractMin()
+			}
+			want := tt.items - tt.deletes - tt.extracts
+			if got, _ := validateFibonacciHeap(t, h); got != want || h.Size() != want {
+				t.Errorf("FibonacciHeap has %v nodes and Size() = %v, want %v", got, h.Size(), want)
+			}
+		})
+	}
+}
+
+func TestFibonacciHeap_consolidate(t *testing.T) {
+	h := NewFibonacciHeap(func(a, b int) bool { return a < b })
+	for i := 0; i < 64; i++ {
+		h.Insert(i)
+	}
+	if _, roots := validateFibonacciHeap(t, h); roots != 64 {
+		t.Errorf("FibonacciHeap has %v roots before ExtractMin, want %v", roots, 64)
+	}
+	h.ExtractMin()
+	// the 63 remaining nodes are consolidated into trees of distinct
+	// degrees, one for every bit of 63.
+	if _, roots := validateFibonacciHeap(t, h); roots != 6 {
+		t.Errorf("FibonacciHeap has %v roots after ExtractMin, want %v", roots, 6)
+	}
+}
@@ -1,3 +1,3 @@
 module github.com/wisdommatt/go-data-structures
 
-go 1.16
+go 1.21
@@ -0,0 +1,460 @@
+package datastructures
+
+import (
+	"math"
+	"reflect"
+)
+
+// HashMap represents a generic hash map data structure.
+//
+// this hash map data structure implementation uses separate chaining
+// technique to handle collisions.
+//
+// the map grows and shrinks automatically when the load factor
+// (Elements()/Size()) crosses the configured thresholds. rehashing is
+// done incrementally: while a rehash is in progress every operation moves
+// a few buckets from the old table to the new one, enough to finish the
+// rehash before the next resize, so no single operation has to move every
+// element.
+type HashMap[K comparable, V any] struct {
+	size          int
+	elementsCount int
+	table         []*DoublyLinkedList
+	// oldTable holds the buckets that have not been moved to table yet
+	// during a rehash, it is nil when no rehash is in progress.
+	oldTable    []*DoublyLinkedList
+	rehashIndex int
+	// rehashStepSize is the number of buckets moved by every operation
+	// during a rehash.
+	rehashStepSize int
+	// ranging is the number of Range calls running, the buckets are not
+	// moved or resized while it is positive.
+	ranging       int
+	minSize       int
+	minLoadFactor float64
+	maxLoadFactor float64
+	hasher        Hasher
+	seed          uint64
+	equal         func(a, b K) bool
+}
+
+// HashMapEntry is the object that represents a hash map entry.
+type HashMapEntry[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// NewHashMap returns a new hash map data structure.
+//
+// the map never shrinks below its initial size.
+func NewHashMap[K comparable, V any](size int, options ...HashTableOption) *HashMap[K, V] {
+	config := newHashTableConfig(defaultHashTableMinLoadFactor, defaultHashTableMaxLoadFactor, options)
+	m := &HashMap[K, V]{
+		size:          size,
+		elementsCount: 0,
+		table:         make([]*DoublyLinkedList, size),
+		minSize:       size,
+		minLoadFactor: config.minLoadFactor,
+		maxLoadFactor: config.maxLoadFactor,
+		hasher:        config.hasher,
+		seed:          config.seed,
+	}
+	// the default hasher compares concrete key types with ==, so the
+	// keys only have to be converted to interface{} when the key type
+	// is an interface or a custom hasher is used.
+	_, isDefaultHasher := config.hasher.(defaultHasher)
+	if isDefaultHasher && reflect.TypeOf((*K)(nil)).Elem().Kind() != reflect.Interface {
+		m.equal = func(a, b K) bool { return a == b }
+	} else {
+		m.equal = func(a, b K) bool { return config.hasher.Equal(a, b) }
+	}
+	return m
+}
+
+// Set sets a new <Key, Value> item in the hash map.
+func (m *HashMap[K, V]) Set(key K, value V) error {
+	node, _, err := m.lookup(key)
+	if err != nil {
+		return err
+	}
+	// if the key already exist, the value in the entry is updated.
+	if node != nil {
+		node.Data = HashMapEntry[K, V]{Key: key, Value: value}
+		return nil
+	}
+	m.add(key, value)
+	return nil
+}
+
+// Get retrieves an item from the hash map using the key.
+//
+// it returns false if the key is not in the hash map.
+func (m *HashMap[K, V]) Get(key K) (V, bool) {
+	node, _, _ := m.lookup(key)
+	if node == nil {
+		var zero V
+		return zero, false
+	}
+	return node.Data.(HashMapEntry[K, V]).Value, true
+}
+
+// GetOrSet returns the value of key if it exist, else it sets the value
+// of key to value and returns it.
+//
+// the returned bool is true if the key already existed.
+func (m *HashMap[K, V]) GetOrSet(key K, value V) (V, bool, error) {
+	node, _, err := m.lookup(key)
+	if err != nil {
+		return value, false, err
+	}
+	if node != nil {
+		return node.Data.(HashMapEntry[K, V]).Value, true, nil
+	}
+	m.add(key, value)
+	return value, false, nil
+}
+
+// Update sets the value of key to the value returned by f, f receives
+// the current value of key and false if the key does not exist.
+func (m *HashMap[K, V]) Update(key K, f func(value V, exists bool) V) error {
+	node, _, err := m.lookup(key)
+	if err != nil {
+		return err
+	}
+	if node != nil {
+		entry := node.Data.(HashMapEntry[K, V])
+		node.Data = HashMapEntry[K, V]{Key: entry.Key, Value: f(entry.Value, true)}
+		return nil
+	}
+	var zero V
+	m.add(key, f(zero, false))
+	return nil
+}
+
+// Delete removes an item from the hash map in key position.
+//
+// it returns false if the key is not in the hash map.
+func (m *HashMap[K, V]) Delete(key K) bool {
+	node, linkedList, _ := m.lookup(key)
+	if node == nil {
+		return false
+	}
+	linkedList.Remove(node)
+	m.elementsCount--
+	m.resizeIfNeeded()
+	return true
+}
+
+// Keys returns the keys of the hash map.
+func (m *HashMap[K, V]) Keys() []K {
+	keys := make([]K, 0, m.elementsCount)
+	m.Range(func(key K, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Values returns the values of the hash map.
+func (m *HashMap[K, V]) Values() []V {
+	values := make([]V, 0, m.elementsCount)
+	m.Range(func(_ K, value V) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+// Range iterates through the hash map and executes the callback function
+// f for each iteration, the iteration stops when f returns false.
+//
+// f can delete any key, every key that is not deleted is visited once. a
+// key set by f may or may not be visited. the map is not resized until the
+// iteration ends.
+func (m *HashMap[K, V]) Range(f func(key K, value V) bool) {
+	m.ranging++
+	defer func() {
+		m.ranging--
+		m.resizeIfNeeded()
+	}()
+	m.iterate(f)
+}
+
+// iterate is a helper method that calls f for every entry like Range, but
+// it does not modify the hash map, so it can be called by concurrent
+// readers.
+func (m *HashMap[K, V]) iterate(f func(key K, value V) bool) {
+	iterateTable := func(table []*DoublyLinkedList) bool {
+		for _, linkedList := range table {
+			if linkedList == nil {
+				continue
+			}
+			// the next node is read before f is called, f can remove
+			// the node from its bucket.
+			for node := linkedList.GetHead(); node != nil; {
+				next := node.Next
+				entry := node.Data.(HashMapEntry[K, V])
+				if !f(entry.Key, entry.Value) {
+					return false
+				}
+				node = next
+			}
+		}
+		return true
+	}
+	if m.oldTable != nil && !iterateTable(m.oldTable[m.rehashIndex:]) {
+		return
+	}
+	iterateTable(m.table)
+}
+
+// Reserve resizes the hash map so that it can hold n elements without
+// growing, the map will not shrink below this size afterwards.
+func (m *HashMap[K, V]) Reserve(n int) {
+	size := n
+	if m.maxLoadFactor > 0 {
+		size = int(math.Ceil(float64(n) / m.maxLoadFactor))
+	}
+	if size > m.minSize {
+		m.minSize = size
+	}
+	if size > m.size {
+		m.resize(size)
+	}
+}
+
+// Size returns the number of buckets in the hash map.
+func (m *HashMap[K, V]) Size() int {
+	return m.size
+}
+
+// Elements returns the number of elements in the hash map.
+func (m *HashMap[K, V]) Elements() int {
+	return m.elementsCount
+}
+
+// LoadFactor returns the number of elements per bucket in the hash map.
+func (m *HashMap[K, V]) LoadFactor() float64 {
+	if m.size == 0 {
+		return 0
+	}
+	return float64(m.elementsCount) / float64(m.size)
+}
+
+// Stats returns the bucket chain statistics of the hash map.
+//
+// a rehash in progress is completed first, so the stats describe a
+// single table.
+func (m *HashMap[K, V]) Stats() HashTableStats {
+	m.finishRehash()
+	stats := newHashTableStats(m.size)
+	for _, linkedList := range m.table {
+		if linkedList == nil || linkedList.Size() == 0 {
+			stats.EmptyBuckets++
+			continue
+		}
+		stats.addChain(linkedList.Size())
+		// the i-th element of a chain is found after i comparisons.
+		for i := 1; i <= linkedList.Size(); i++ {
+			stats.addProbes(i)
+		}
+	}
+	return stats.finish()
+}
+
+// lookup is a helper method that hashes key, moves the next buckets of a
+// rehash in progress and returns the node of key and the bucket that
+// contains it.
+func (m *HashMap[K, V]) lookup(key K) (*DoublyLinkedListNode, *DoublyLinkedList, error) {
+	keyHash, err := m.hasher.Hash(key, m.seed)
+	if err != nil {
+		return nil, nil, err
+	}
+	m.rehashStep()
+	node, linkedList := m.findNode(keyHash, key)
+	return node, linkedList, nil
+}
+
+// peek is a helper method that returns the node of key without moving
+// the buckets of a rehash in progress, so it does not modify the hash map
+// and can be called by concurrent readers.
+func (m *HashMap[K, V]) peek(key K) (*DoublyLinkedListNode, error) {
+	keyHash, err := m.hasher.Hash(key, m.seed)
+	if err != nil {
+		return nil, err
+	}
+	node, _ := m.findNode(keyHash, key)
+	return node, nil
+}
+
+// add is a helper method that adds a key that is not in the hash map.
+func (m *HashMap[K, V]) add(key K, value V) {
+	if m.size == 0 {
+		m.resize(1)
+	}
+	// the key was hashed successfully by lookup.
+	keyHash, _ := m.hasher.Hash(key, m.seed)
+	m.addToTable(keyHash, HashMapEntry[K, V]{Key: key, Value: value})
+	m.elementsCount++
+	m.resizeIfNeeded()
+}
+
+// resizeIfNeeded is a helper method that grows or shrinks the hash map
+// when its load factor crossed a threshold, unless Range is running.
+//
+// the size is doubled or halved until the load factor is within the
+// thresholds, a Range can delete or set many keys before the resize.
+func (m *HashMap[K, V]) resizeIfNeeded() {
+	if m.ranging > 0 {
+		return
+	}
+	loadFactor := func(size int) float64 {
+		return float64(m.elementsCount) / float64(size)
+	}
+	switch {
+	case m.maxLoadFactor > 0 && m.LoadFactor() > m.maxLoadFactor:
+		newSize := m.size * 2
+		for loadFactor(newSize) > m.maxLoadFactor {
+			newSize *= 2
+		}
+		m.resize(newSize)
+	case m.minLoadFactor > 0 && m.size > m.minSize && m.LoadFactor() < m.minLoadFactor:
+		newSize := m.size / 2
+		for newSize > m.minSize && loadFactor(newSize) < m.minLoadFactor {
+			newSize /= 2
+		}
+		m.resize(max(newSize, m.minSize))
+	}
+}
+
+// findNode is a helper method to find the node of key in the hash map,
+// it returns the node and the bucket that contains it.
+func (m *HashMap[K, V]) findNode(keyHash uint64, key K) (*DoublyLinkedListNode, *DoublyLinkedList) {
+	buckets := []*DoublyLinkedList{}
+	if m.size > 0 {
+		buckets = append(buckets, m.table[keyHash%uint64(m.size)])
+	}
+	// the key might still be in a bucket of the old table that has
+	// not been moved yet.
+	if m.oldTable != nil {
+		oldIndex := int(keyHash % uint64(len(m.oldTable)))
+		if oldIndex >= m.rehashIndex {
+			buckets = append(buckets, m.oldTable[oldIndex])
+		}
+	}
+	for _, linkedList := range buckets {
+		if linkedList == nil {
+			continue
+		}
+		for node := linkedList.GetHead(); node != nil; {
+			next := node.Next
+			if m.equal(node.Data.(HashMapEntry[K, V]).Key, key) {
+				return node, linkedList
+			}
+			node = next
+		}
+	}
+	return nil, nil
+}
+
+// addToTable is a helper method to add an entry to the bucket of keyHash
+// in the current table.
+func (m *HashMap[K, V]) addToTable(keyHash uint64, entry HashMapEntry[K, V]) {
+	index := keyHash % uint64(m.size)
+	if m.table[index] == nil {
+		m.table[index] = NewDoublyLinkedList()
+	}
+	m.table[index].Add(&DoublyLinkedListNode{Data: entry})
+}
+
+// resize starts moving the hash map elements to a new table with
+// newSize buckets.
+//
+// a rehash started by a grow or a shrink is always finished by then, only
+// Reserve can resize while a rehash is in progress, which completes it
+// first.
+func (m *HashMap[K, V]) resize(newSize int) {
+	if newSize < 1 {
+		newSize = 1
+	}
+	m.finishRehash()
+	oldTable := m.table
+	m.table = make([]*DoublyLinkedList, newSize)
+	m.size = newSize
+	if m.elementsCount == 0 {
+		return
+	}
+	m.oldTable = oldTable
+	m.rehashIndex = 0
+	// the last operation before the next resize is not counted, so that
+	// the rounding of the load factors cannot leave a bucket behind.
+	operations := max(m.operationsBeforeResize()-1, 1)
+	m.rehashStepSize = max((len(oldTable)-1)/operations+1, hashTableMinRehashStep)
+}
+
+// operationsBeforeResize returns the least number of operations before
+// the number of elements crosses a load factor threshold, it is at least 1.
+func (m *HashMap[K, V]) operationsBeforeResize() int {
+	operations := math.MaxInt
+	if m.maxLoadFactor > 0 {
+		// the map grows when it has more than maxLoadFactor*size elements.
+		operations = int(m.maxLoadFactor*float64(m.size)) + 1 - m.elementsCount
+	}
+	if m.minLoadFactor > 0 && m.size > m.minSize {
+		// the map shrinks when it has less than minLoadFactor*size elements.
+		shrink := m.elementsCount + 1 - int(math.Ceil(m.minLoadFactor*float64(m.size)))
+		operations = min(operations, shrink)
+	}
+	return max(operations, 1)
+}
+
+// rehashStep moves rehashStepSize buckets from the old table to the
+// current table if a rehash is in progress and Range is not running.
+func (m *HashMap[K, V]) rehashStep() {
+	if m.ranging == 0 {
+		m.moveBuckets(m.rehashStepSize)
+	}
+}
+
+// finishRehash moves every bucket left in the old table to the current
+// table.
+func (m *HashMap[K, V]) finishRehash() {
+	if m.oldTable != nil {
+		m.moveBuckets(len(m.oldTable))
+	}
+}
+
+// moveBuckets moves up to count buckets from the old table to the current
+// table if a rehash is in progress.
+func (m *HashMap[K, V]) moveBuckets(count int) {
+	if m.oldTable == nil {
+		return
+	}
+	for i := 0; i < count && m.rehashIndex < len(m.oldTable); i++ {
+		linkedList := m.oldTable[m.rehashIndex]
+		if linkedList != nil {
+			linkedList.Iterate(func(_ int, node *DoublyLinkedListNode) {
+				entry := node.Data.(HashMapEntry[K, V])
+				// the key was hashed successfully when it was added.
+				keyHash, _ := m.hasher.Hash(entry.Key, m.seed)
+				m.addToTable(keyHash, entry)
+			})
+			m.oldTable[m.rehashIndex] = nil
+		}
+		m.rehashIndex++
+	}
+	if m.rehashIndex == len(m.oldTable) {
+		m.oldTable = nil
+		m.rehashIndex = 0
+	}
+}
+
+// hash is the hash function for hashing keys, it returns the bucket of
+// the key in the current table.
+func (m *HashMap[K, V]) hash(key K) (int, error) {
+	keyHash, err := m.hasher.Hash(key, m.seed)
+	if err != nil {
+		return 0, err
+	}
+	return int(keyHash % uint64(m.size)), nil
+}
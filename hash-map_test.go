@@ -0,0 +1,403 @@
+package datastructures
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+func TestHashMap_SetGet(t *testing.T) {
+	tests := []struct {
+		name      string
+		items     map[string]int
+		key       string
+		want      int
+		wantFound bool
+	}{
+		{
+			name:      "existing key",
+			items:     map[string]int{"one": 1, "two": 2, "three": 3},
+			key:       "two",
+			want:      2,
+			wantFound: true,
+		},
+		{
+			name:  "non-existing key",
+			items: map[string]int{"one": 1, "two": 2},
+			key:   "three",
+		},
+		{
+			name: "empty hash map",
+			key:  "one",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewHashMap[string, int](2)
+			for key, value := range tt.items {
+				if err := m.Set(key, value); err != nil {
+					t.Fatalf("HashMap.Set() error = %v", err)
+				}
+			}
+			got, found := m.Get(tt.key)
+			if found != tt.wantFound || got != tt.want {
+				t.Errorf("HashMap.Get() = %v, %v, want %v, %v", got, found, tt.want, tt.wantFound)
+			}
+			if m.Elements() != len(tt.items) {
+				t.Errorf("HashMap.Elements() = %v, want %v", m.Elements(), len(tt.items))
+			}
+		})
+	}
+}
+
+func TestHashMap_Set(t *testing.T) {
+	t.Run("updating existing key", func(t *testing.T) {
+		m := NewHashMap[int, string](4)
+		m.Set(1, "first")
+		m.Set(1, "updated")
+		if got, _ := m.Get(1); got != "updated" {
+			t.Errorf("HashMap.Get() = %v, want %v", got, "updated")
+		}
+		if m.Elements() != 1 {
+			t.Errorf("HashMap.Elements() = %v, want %v", m.Elements(), 1)
+		}
+	})
+
+	t.Run("unsupported key", func(t *testing.T) {
+		m := NewHashMap[float64, string](4)
+		if err := m.Set(math.NaN(), "nan"); !errors.Is(err, ErrUnsupportedKey) {
+			t.Errorf("HashMap.Set() error = %v, wantErr %v", err, ErrUnsupportedKey)
+		}
+		if m.Elements() != 0 {
+			t.Errorf("HashMap.Elements() = %v, want %v", m.Elements(), 0)
+		}
+	})
+
+	t.Run("hashable struct key", func(t *testing.T) {
+		m := NewHashMap[hashablePoint, string](4)
+		m.Set(hashablePoint{1, 2}, "a")
+		m.Set(hashablePoint{2, 1}, "b")
+		if got, _ := m.Get(hashablePoint{1, 2}); got != "a" {
+			t.Errorf("HashMap.Get() = %v, want %v", got, "a")
+		}
+	})
+}
+
+func TestHashMap_Delete(t *testing.T) {
+	tests := []struct {
+		name         string
+		items        []int
+		key          int
+		want         bool
+		wantElements int
+	}{
+		{
+			name:         "existing key",
+			items:        []int{1, 2, 3},
+			key:          2,
+			want:         true,
+			wantElements: 2,
+		},
+		{
+			name:         "non-existing key",
+			items:        []int{1, 2, 3},
+			key:          4,
+			wantElements: 3,
+		},
+		{
+			name: "empty hash map",
+			key:  1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewHashMap[int, int](2)
+			for _, item := range tt.items {
+				m.Set(item, item)
+			}
+			if got := m.Delete(tt.key); got != tt.want {
+				t.Errorf("HashMap.Delete() = %v, want %v", got, tt.want)
+			}
+			if _, found := m.Get(tt.key); found {
+				t.Errorf("HashMap.Get() found a deleted key")
+			}
+			if m.Elements() != tt.wantElements {
+				t.Errorf("HashMap.Elements() = %v, want %v", m.Elements(), tt.wantElements)
+			}
+		})
+	}
+}
+
+func TestHashMap_GetOrSet(t *testing.T) {
+	m := NewHashMap[string, int](4)
+	got, loaded, err := m.GetOrSet("a", 1)
+	if err != nil || loaded || got != 1 {
+		t.Errorf("HashMap.GetOrSet() = %v, %v, %v, want %v, %v, %v", got, loaded, err, 1, false, nil)
+	}
+	got, loaded, err = m.GetOrSet("a", 2)
+	if err != nil || !loaded || got != 1 {
+		t.Errorf("HashMap.GetOrSet() = %v, %v, %v, want %v, %v, %v", got, loaded, err, 1, true, nil)
+	}
+	if m.Elements() != 1 {
+		t.Errorf("HashMap.Elements() = %v, want %v", m.Elements(), 1)
+	}
+}
+
+func TestHashMap_Update(t *testing.T) {
+	m := NewHashMap[string, int](4)
+	increment := func(value int, exists bool) int {
+		if !exists {
+			return 1
+		}
+		return value + 1
+	}
+	for _, word := range []string{"a", "b", "a", "c", "a", "b"} {
+		if err := m.Update(word, increment); err != nil {
+			t.Fatalf("HashMap.Update() error = %v", err)
+		}
+	}
+	want := map[string]int{"a": 3, "b": 2, "c": 1}
+	for key, value := range want {
+		if got, _ := m.Get(key); got != value {
+			t.Errorf("HashMap.Get(%v) = %v, want %v", key, got, value)
+		}
+	}
+}
+
+func TestHashMap_KeysValues(t *testing.T) {
+	m := NewHashMap[int, string](2)
+	for i := 0; i < 20; i++ {
+		m.Set(i, strconv.Itoa(i))
+	}
+	keys := m.Keys()
+	sort.Ints(keys)
+	values := m.Values()
+	sort.Slice(values, func(i, j int) bool {
+		a, _ := strconv.Atoi(values[i])
+		b, _ := strconv.Atoi(values[j])
+		return a < b
+	})
+	for i := 0; i < 20; i++ {
+		if keys[i] != i || values[i] != strconv.Itoa(i) {
+			t.Fatalf("HashMap.Keys() = %v, HashMap.Values() = %v", keys, values)
+		}
+	}
+}
+
+func TestHashMap_Range(t *testing.T) {
+	tests := []struct {
+		name      string
+		items     int
+		stopAfter int
+		want      int
+	}{
+		{
+			name:      "iterating every item",
+			items:     10,
+			stopAfter: -1,
+			want:      10,
+		},
+		{
+			name:      "stopping the iteration",
+			items:     10,
+			stopAfter: 3,
+			want:      3,
+		},
+		{
+			name:      "empty hash map",
+			stopAfter: -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewHashMap[int, int](4)
+			for i := 0; i < tt.items; i++ {
+				m.Set(i, i)
+			}
+			count := 0
+			m.Range(func(key, value int) bool {
+				count++
+				return count != tt.stopAfter
+			})
+			if count != tt.want {
+				t.Errorf("HashMap.Range() count = %v, want %v", count, tt.want)
+			}
+		})
+	}
+}
+
+func TestHashMap_hasherEquality(t *testing.T) {
+	t.Run("interface keys use the hasher", func(t *testing.T) {
+		m := NewHashMap[interface{}, int](4)
+		m.Set([]byte("key"), 1)
+		if got, _ := m.Get([]byte("key")); got != 1 {
+			t.Errorf("HashMap.Get() = %v, want %v", got, 1)
+		}
+	})
+
+	t.Run("custom hasher", func(t *testing.T) {
+		m := NewHashMap[string, int](4, HashTableHasher(caseInsensitiveHasher{}))
+		m.Set("Key", 1)
+		m.Set("KEY", 2)
+		if got, _ := m.Get("key"); got != 2 || m.Elements() != 1 {
+			t.Errorf("HashMap.Get() = %v, elements %v, want %v, %v", got, m.Elements(), 2, 1)
+		}
+	})
+}
+
+func TestHashMap_resize(t *testing.T) {
+	m := NewHashMap[int, int](2)
+	for i := 0; i < 100; i++ {
+		m.Set(i, i)
+	}
+	for i := 0; i < 90; i++ {
+		m.Delete(i)
+	}
+	want := []int{90, 91, 92, 93, 94, 95, 96, 97, 98, 99}
+	keys := m.Keys()
+	sort.Ints(keys)
+	if !reflect.DeepEqual(keys, want) {
+		t.Errorf("HashMap.Keys() = %v, want %v", keys, want)
+	}
+	if m.Size() >= 128 {
+		t.Errorf("HashMap.Size() = %v, want the map to shrink", m.Size())
+	}
+}
+
+func TestHashMap_rehashBeforeResize(t *testing.T) {
+	const keys = 1 << 14
+	tests := []struct {
+		name    string
+		options []HashTableOption
+		// maxStep is the most buckets a single operation may move.
+		maxStep int
+	}{
+		{name: "default load factors", maxStep: 16},
+		{name: "low max load factor", options: []HashTableOption{HashTableLoadFactors(0, 0.25)}, maxStep: 16},
+		{name: "close load factors", options: []HashTableOption{HashTableLoadFactors(0.3, 0.75)}, maxStep: 16},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewHashMap[int, int](1, append(tt.options, HashTableSeed(1))...)
+			// check runs an operation and fails if it resizes the map while
+			// its step cannot finish a rehash in progress.
+			check := func(operation func()) {
+				t.Helper()
+				size, step, pending := m.size, m.rehashStepSize, 0
+				if m.oldTable != nil {
+					pending = len(m.oldTable) - m.rehashIndex
+				}
+				operation()
+				if m.size != size && pending > step {
+					t.Fatalf("HashMap resized to %v buckets with %v buckets of a rehash left", m.size, pending)
+				}
+				if m.oldTable != nil && m.rehashStepSize > tt.maxStep {
+					t.Fatalf("HashMap moves %v buckets per operation, want at most %v", m.rehashStepSize, tt.maxStep)
+				}
+			}
+			for i := 0; i < keys; i++ {
+				check(func() { m.Set(i, i) })
+			}
+			for i := 0; i < keys; i++ {
+				check(func() { m.Delete(i) })
+			}
+			for i := 0; i < keys; i++ {
+				check(func() { m.Set(i, i) })
+				if i%3 == 0 {
+					check(func() { m.Delete(i / 2) })
+				}
+			}
+		})
+	}
+}
+
+func TestHashMap_Range_delete(t *testing.T) {
+	tests := []struct {
+		name    string
+		size    int
+		options []HashTableOption
+		// rehashing starts a rehash that is in progress during the range.
+		rehashing bool
+	}{
+		{name: "resizing disabled", size: 4, options: []HashTableOption{HashTableLoadFactors(0, 0)}},
+		{name: "default load factors", size: 4},
+		{name: "rehash in progress", size: 4, rehashing: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewHashMap[int, int](tt.size, tt.options...)
+			for i := 0; i < 16; i++ {
+				m.Set(i, i)
+			}
+			if tt.rehashing {
+				m.resize(64)
+			}
+			visited := map[int]int{}
+			m.Range(func(key, _ int) bool {
+				visited[key]++
+				m.Delete(key)
+				return true
+			})
+			if len(visited) != 16 {
+				t.Errorf("HashMap.Range() visited %v keys, want %v", len(visited), 16)
+			}
+			for key, count := range visited {
+				if count != 1 {
+					t.Errorf("HashMap.Range() visited %v %v times", key, count)
+				}
+			}
+			if m.Elements() != 0 {
+				t.Errorf("HashMap.Elements() = %v, want %v", m.Elements(), 0)
+			}
+			if m.Size() != tt.size && tt.options == nil {
+				t.Errorf("HashMap.Size() = %v, want the map to shrink to %v", m.Size(), tt.size)
+			}
+		})
+	}
+}
+
+func TestHashMap_comparableKeys(t *testing.T) {
+	t.Run("bool keys", func(t *testing.T) {
+		m := NewHashMap[bool, string](2)
+		if err := m.Set(true, "yes"); err != nil {
+			t.Fatalf("HashMap.Set() error = %v", err)
+		}
+		if err := m.Set(false, "no"); err != nil {
+			t.Fatalf("HashMap.Set() error = %v", err)
+		}
+		if got, ok := m.Get(true); !ok || got != "yes" {
+			t.Errorf("HashMap.Get(true) = %v, %v, want %v", got, ok, "yes")
+		}
+		if got, ok := m.Get(false); !ok || got != "no" {
+			t.Errorf("HashMap.Get(false) = %v, %v, want %v", got, ok, "no")
+		}
+	})
+	t.Run("struct keys", func(t *testing.T) {
+		type point struct {
+			X, Y int
+		}
+		m := NewHashMap[point, int](4)
+		for x := 0; x < 10; x++ {
+			for y := 0; y < 10; y++ {
+				if err := m.Set(point{x, y}, x*10+y); err != nil {
+					t.Fatalf("HashMap.Set() error = %v", err)
+				}
+			}
+		}
+		if m.Elements() != 100 {
+			t.Errorf("HashMap.Elements() = %v, want %v", m.Elements(), 100)
+		}
+		if got, ok := m.Get(point{3, 7}); !ok || got != 37 {
+			t.Errorf("HashMap.Get() = %v, %v, want %v", got, ok, 37)
+		}
+		if !m.Delete(point{3, 7}) {
+			t.Errorf("HashMap.Delete() = false, want true")
+		}
+		if _, ok := m.Get(point{3, 7}); ok {
+			t.Errorf("HashMap.Get() of a deleted key = true, want false")
+		}
+	})
+}
@@ -0,0 +1,176 @@
+package datastructures
+
+// HashSet represents a generic set data structure built on HashMap.
+//
+// the set accepts the same options as the hash map, the sets returned by
+// the set algebra methods use the options of the receiver. sets that are
+// combined should use the same hasher, the set algebra methods return an
+// error if an item of one set cannot be hashed by the other.
+type HashSet[T comparable] struct {
+	items *HashMap[T, struct{}]
+}
+
+// NewHashSet returns a new hash set data structure that contains items.
+//
+// it returns an error if one of the items cannot be hashed.
+func NewHashSet[T comparable](size int, items []T, options ...HashTableOption) (*HashSet[T], error) {
+	s := &HashSet[T]{items: NewHashMap[T, struct{}](size, options...)}
+	for _, item := range items {
+		if err := s.Add(item); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Add adds item to the hash set.
+func (s *HashSet[T]) Add(item T) error {
+	return s.items.Set(item, struct{}{})
+}
+
+// Remove removes item from the hash set.
+//
+// it returns false if item is not in the hash set.
+func (s *HashSet[T]) Remove(item T) bool {
+	return s.items.Delete(item)
+}
+
+// Contains returns true if item is in the hash set.
+func (s *HashSet[T]) Contains(item T) bool {
+	_, found := s.items.Get(item)
+	return found
+}
+
+// Len returns the number of items in the hash set.
+func (s *HashSet[T]) Len() int {
+	return s.items.Elements()
+}
+
+// Items returns the items of the hash set.
+func (s *HashSet[T]) Items() []T {
+	return s.items.Keys()
+}
+
+// Range iterates through the hash set and executes the callback function
+// f for each iteration, the iteration stops when f returns false.
+func (s *HashSet[T]) Range(f func(item T) bool) {
+	s.items.Range(func(item T, _ struct{}) bool {
+		return f(item)
+	})
+}
+
+// Union returns a new hash set with the items that are in s or other.
+func (s *HashSet[T]) Union(other *HashSet[T]) (*HashSet[T], error) {
+	union := s.newSet(s.Len() + other.Len())
+	if err := union.addItems(s, nil); err != nil {
+		return nil, err
+	}
+	if err := union.addItems(other, nil); err != nil {
+		return nil, err
+	}
+	return union, nil
+}
+
+// Intersection returns a new hash set with the items that are in both s
+// and other.
+func (s *HashSet[T]) Intersection(other *HashSet[T]) (*HashSet[T], error) {
+	// iterating the smaller set makes the intersection cheaper.
+	smaller, larger := s, other
+	if other.Len() < s.Len() {
+		smaller, larger = other, s
+	}
+	intersection := s.newSet(smaller.Len())
+	if err := intersection.addItems(smaller, larger.contains); err != nil {
+		return nil, err
+	}
+	return intersection, nil
+}
+
+// Difference returns a new hash set with the items of s that are not in
+// other.
+func (s *HashSet[T]) Difference(other *HashSet[T]) (*HashSet[T], error) {
+	difference := s.newSet(s.Len())
+	if err := difference.addItems(s, other.excludes); err != nil {
+		return nil, err
+	}
+	return difference, nil
+}
+
+// SymmetricDifference returns a new hash set with the items that are in
+// either s or other but not in both.
+func (s *HashSet[T]) SymmetricDifference(other *HashSet[T]) (*HashSet[T], error) {
+	difference, err := s.Difference(other)
+	if err != nil {
+		return nil, err
+	}
+	if err := difference.addItems(other, s.excludes); err != nil {
+		return nil, err
+	}
+	return difference, nil
+}
+
+// IsSubset returns true if every item of s is in other.
+func (s *HashSet[T]) IsSubset(other *HashSet[T]) bool {
+	if s.Len() > other.Len() {
+		return false
+	}
+	isSubset := true
+	s.Range(func(item T) bool {
+		isSubset = other.Contains(item)
+		return isSubset
+	})
+	return isSubset
+}
+
+// Equal returns true if s and other contain the same items.
+func (s *HashSet[T]) Equal(other *HashSet[T]) bool {
+	return s.Len() == other.Len() && s.IsSubset(other)
+}
+
+// newSet is a helper method that returns an empty hash set with the
+// options of s.
+func (s *HashSet[T]) newSet(size int) *HashSet[T] {
+	if size < 1 {
+		size = 1
+	}
+	items := NewHashMap[T, struct{}](
+		size,
+		HashTableLoadFactors(s.items.minLoadFactor, s.items.maxLoadFactor),
+		HashTableHasher(s.items.hasher),
+		HashTableSeed(s.items.seed),
+	)
+	return &HashSet[T]{items: items}
+}
+
+// addItems is a helper method that adds the items of from for which keep
+// returns true, or every item when keep is nil. it stops at the first
+// error, an item of from can fail to hash when the sets use different
+// hashers.
+func (s *HashSet[T]) addItems(from *HashSet[T], keep func(item T) (bool, error)) error {
+	var err error
+	from.Range(func(item T) bool {
+		add := true
+		if keep != nil {
+			add, err = keep(item)
+		}
+		if err == nil && add {
+			err = s.Add(item)
+		}
+		return err == nil
+	})
+	return err
+}
+
+// contains is a helper method that returns true if item is in the hash
+// set, or an error if item cannot be hashed.
+func (s *HashSet[T]) contains(item T) (bool, error) {
+	node, _, err := s.items.lookup(item)
+	return node != nil, err
+}
+
+// excludes is a helper method that returns true if item is not in the hash
+// set, or an error if item cannot be hashed.
+func (s *HashSet[T]) excludes(item T) (bool, error) {
+	found, err := s.contains(item)
+	return !found, err
+}
@@ -0,0 +1,273 @@
+package datastructures
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func newIntHashSet(t *testing.T, items ...int) *HashSet[int] {
+	t.Helper()
+	s, err := NewHashSet(2, items)
+	if err != nil {
+		t.Fatalf("NewHashSet() error = %v", err)
+	}
+	return s
+}
+
+func sortedHashSetItems(s *HashSet[int]) []int {
+	items := s.Items()
+	sort.Ints(items)
+	return items
+}
+
+func TestNewHashSet(t *testing.T) {
+	t.Run("duplicate items", func(t *testing.T) {
+		s := newIntHashSet(t, 1, 2, 2, 3, 1)
+		if got := sortedHashSetItems(s); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+			t.Errorf("HashSet.Items() = %v, want %v", got, []int{1, 2, 3})
+		}
+	})
+
+	t.Run("unsupported item", func(t *testing.T) {
+		if _, err := NewHashSet(2, []float64{1, math.NaN()}); !errors.Is(err, ErrUnsupportedKey) {
+			t.Errorf("NewHashSet() error = %v, wantErr %v", err, ErrUnsupportedKey)
+		}
+	})
+}
+
+func TestHashSet_AddRemoveContains(t *testing.T) {
+	tests := []struct {
+		name        string
+		add         []int
+		remove      []int
+		want        []int
+		wantMissing []int
+		wantRemoved []bool
+	}{
+		{
+			name:        "adding items",
+			add:         []int{1, 2, 3},
+			want:        []int{1, 2, 3},
+			wantMissing: []int{4},
+		},
+		{
+			name:        "removing items",
+			add:         []int{1, 2, 3},
+			remove:      []int{2, 4},
+			want:        []int{1, 3},
+			wantMissing: []int{2, 4},
+			wantRemoved: []bool{true, false},
+		},
+		{
+			name:        "empty hash set",
+			remove:      []int{1},
+			wantMissing: []int{1},
+			wantRemoved: []bool{false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newIntHashSet(t)
+			for _, item := range tt.add {
+				s.Add(item)
+			}
+			for i, item := range tt.remove {
+				if got := s.Remove(item); got != tt.wantRemoved[i] {
+					t.Errorf("HashSet.Remove(%v) = %v, want %v", item, got, tt.wantRemoved[i])
+				}
+			}
+			for _, item := range tt.want {
+				if !s.Contains(item) {
+					t.Errorf("HashSet.Contains(%v) = false, want true", item)
+				}
+			}
+			for _, item := range tt.wantMissing {
+				if s.Contains(item) {
+					t.Errorf("HashSet.Contains(%v) = true, want false", item)
+				}
+			}
+			if s.Len() != len(tt.want) {
+				t.Errorf("HashSet.Len() = %v, want %v", s.Len(), len(tt.want))
+			}
+		})
+	}
+}
+
+func TestHashSet_algebra(t *testing.T) {
+	tests := []struct {
+		name                    string
+		a, b                    []int
+		wantUnion               []int
+		wantIntersection        []int
+		wantDifference          []int
+		wantSymmetricDifference []int
+	}{
+		{
+			name:                    "overlapping sets",
+			a:                       []int{1, 2, 3, 4},
+			b:                       []int{3, 4, 5},
+			wantUnion:               []int{1, 2, 3, 4, 5},
+			wantIntersection:        []int{3, 4},
+			wantDifference:          []int{1, 2},
+			wantSymmetricDifference: []int{1, 2, 5},
+		},
+		{
+			name:                    "disjoint sets",
+			a:                       []int{1, 2},
+			b:                       []int{3},
+			wantUnion:               []int{1, 2, 3},
+			wantIntersection:        []int{},
+			wantDifference:          []int{1, 2},
+			wantSymmetricDifference: []int{1, 2, 3},
+		},
+		{
+			name:                    "empty set",
+			a:                       []int{},
+			b:                       []int{1},
+			wantUnion:               []int{1},
+			wantIntersection:        []int{},
+			wantDifference:          []int{},
+			wantSymmetricDifference: []int{1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := newIntHashSet(t, tt.a...), newIntHashSet(t, tt.b...)
+			results := []struct {
+				method string
+				set    func(other *HashSet[int]) (*HashSet[int], error)
+				want   []int
+			}{
+				{method: "Union", set: a.Union, want: tt.wantUnion},
+				{method: "Intersection", set: a.Intersection, want: tt.wantIntersection},
+				{method: "Difference", set: a.Difference, want: tt.wantDifference},
+				{method: "SymmetricDifference", set: a.SymmetricDifference, want: tt.wantSymmetricDifference},
+			}
+			for _, result := range results {
+				set, err := result.set(b)
+				if err != nil {
+					t.Fatalf("HashSet.%v() error = %v", result.method, err)
+				}
+				if got := sortedHashSetItems(set); !reflect.DeepEqual(got, result.want) {
+					t.Errorf("HashSet.%v() = %v, want %v", result.method, got, result.want)
+				}
+			}
+			// the operands must not be modified.
+			if a.Len() != len(tt.a) || b.Len() != len(tt.b) {
+				t.Errorf("HashSet operands modified: %v, %v", a.Items(), b.Items())
+			}
+		})
+	}
+}
+
+func TestHashSet_IsSubsetEqual(t *testing.T) {
+	tests := []struct {
+		name         string
+		a, b         []int
+		wantIsSubset bool
+		wantEqual    bool
+	}{
+		{name: "subset", a: []int{1, 2}, b: []int{1, 2, 3}, wantIsSubset: true},
+		{name: "superset", a: []int{1, 2, 3}, b: []int{1, 2}},
+		{name: "equal sets", a: []int{3, 1, 2}, b: []int{1, 2, 3}, wantIsSubset: true, wantEqual: true},
+		{name: "same size different items", a: []int{1, 2}, b: []int{1, 3}},
+		{name: "empty set", a: []int{}, b: []int{1}, wantIsSubset: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := newIntHashSet(t, tt.a...), newIntHashSet(t, tt.b...)
+			if got := a.IsSubset(b); got != tt.wantIsSubset {
+				t.Errorf("HashSet.IsSubset() = %v, want %v", got, tt.wantIsSubset)
+			}
+			if got := a.Equal(b); got != tt.wantEqual {
+				t.Errorf("HashSet.Equal() = %v, want %v", got, tt.wantEqual)
+			}
+		})
+	}
+}
+
+func TestHashSet_Range(t *testing.T) {
+	s := newIntHashSet(t, 1, 2, 3, 4, 5)
+	seen := []int{}
+	s.Range(func(item int) bool {
+		seen = append(seen, item)
+		return len(seen) < 3
+	})
+	if len(seen) != 3 {
+		t.Errorf("HashSet.Range() visited %v items, want %v", len(seen), 3)
+	}
+}
+
+func TestHashSet_options(t *testing.T) {
+	s, _ := NewHashSet(2, []string{"Go", "RUST"}, HashTableHasher(caseInsensitiveHasher{}))
+	other, _ := NewHashSet(2, []string{"go"}, HashTableHasher(caseInsensitiveHasher{}))
+	union, err := s.Union(other)
+	if err != nil {
+		t.Fatalf("HashSet.Union() error = %v", err)
+	}
+	if union.Len() != 2 || !union.Contains("rust") {
+		t.Errorf("HashSet.Union() = %v, want the hasher of the receiver", union.Items())
+	}
+}
+
+// shortStringHasher is a Hasher that rejects strings longer than 3 bytes.
+type shortStringHasher struct{}
+
+func (shortStringHasher) Hash(key interface{}, seed uint64) (uint64, error) {
+	if len(key.(string)) > 3 {
+		return 0, fmt.Errorf("%w: %v is too long", ErrUnsupportedKey, key)
+	}
+	return DefaultHasher.Hash(key, seed)
+}
+
+func (shortStringHasher) Equal(a, b interface{}) bool {
+	return a == b
+}
+
+func TestHashSet_algebraErrors(t *testing.T) {
+	short, _ := NewHashSet(2, []string{"a", "b", "c"}, HashTableHasher(shortStringHasher{}))
+	long, _ := NewHashSet(2, []string{"a", "long"})
+	tests := []struct {
+		name      string
+		operation func() (*HashSet[string], error)
+	}{
+		{name: "Union", operation: func() (*HashSet[string], error) { return short.Union(long) }},
+		{name: "Intersection", operation: func() (*HashSet[string], error) { return short.Intersection(long) }},
+		{name: "Difference", operation: func() (*HashSet[string], error) { return long.Difference(short) }},
+		{name: "SymmetricDifference", operation: func() (*HashSet[string], error) { return short.SymmetricDifference(long) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := tt.operation(); !errors.Is(err, ErrUnsupportedKey) {
+				t.Errorf("HashSet.%v() = %v, %v, wantErr %v", tt.name, got, err, ErrUnsupportedKey)
+			}
+		})
+	}
+}
+
+func TestHashSet_structs(t *testing.T) {
+	type point struct {
+		X, Y int
+	}
+	a, err := NewHashSet(2, []point{{1, 2}, {3, 4}, {5, 6}})
+	if err != nil {
+		t.Fatalf("NewHashSet() error = %v", err)
+	}
+	b, _ := NewHashSet(2, []point{{3, 4}, {7, 8}})
+	union, err := a.Union(b)
+	if err != nil || union.Len() != 4 {
+		t.Errorf("HashSet.Union() = %v, %v, want 4 items", union.Items(), err)
+	}
+	intersection, err := a.Intersection(b)
+	if err != nil || intersection.Len() != 1 || !intersection.Contains(point{3, 4}) {
+		t.Errorf("HashSet.Intersection() = %v, %v, want %v", intersection.Items(), err, []point{{3, 4}})
+	}
+	bools, err := NewHashSet(2, []bool{true, false, true})
+	if err != nil || bools.Len() != 2 {
+		t.Errorf("NewHashSet() = %v, %v, want 2 items", bools.Items(), err)
+	}
+}
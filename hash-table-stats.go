@@ -0,0 +1,112 @@
+package datastructures
+
+// HashTableStats describes how the elements of a hash table are spread
+// across its buckets, it is used to detect bad hash distributions.
+//
+// for open addressing hash tables a bucket is a slot and a chain is a
+// cluster, a run of consecutive occupied slots.
+type HashTableStats struct {
+	Buckets    int
+	Elements   int
+	LoadFactor float64
+	// EmptyBuckets is the number of buckets that hold no element.
+	EmptyBuckets int
+	// LongestChain is the length of the longest chain.
+	LongestChain int
+	// ChainLengths maps a chain length to the number of chains of that
+	// length, empty buckets are not counted.
+	ChainLengths map[int]int
+	// AverageProbes is the average number of buckets or slots checked to
+	// find a key of the hash table.
+	AverageProbes float64
+	// MaxProbes is the largest number of buckets or slots checked to find
+	// a key of the hash table.
+	MaxProbes int
+
+	totalProbes int
+}
+
+// newHashTableStats returns empty stats for a hash table with buckets
+// buckets.
+func newHashTableStats(buckets int) HashTableStats {
+	return HashTableStats{
+		Buckets:      buckets,
+		ChainLengths: map[int]int{},
+	}
+}
+
+// addChain is a helper method that records a chain of length elements.
+func (s *HashTableStats) addChain(length int) {
+	if length == 0 {
+		return
+	}
+	s.ChainLengths[length]++
+	if length > s.LongestChain {
+		s.LongestChain = length
+	}
+}
+
+// addProbes is a helper method that records an element that is found
+// after checking probes buckets or slots.
+func (s *HashTableStats) addProbes(probes int) {
+	s.Elements++
+	s.totalProbes += probes
+	if probes > s.MaxProbes {
+		s.MaxProbes = probes
+	}
+}
+
+// merge is a helper method that adds the stats of other to s.
+func (s *HashTableStats) merge(other HashTableStats) {
+	s.Buckets += other.Buckets
+	s.Elements += other.Elements
+	s.EmptyBuckets += other.EmptyBuckets
+	s.totalProbes += other.totalProbes
+	if other.LongestChain > s.LongestChain {
+		s.LongestChain = other.LongestChain
+	}
+	if other.MaxProbes > s.MaxProbes {
+		s.MaxProbes = other.MaxProbes
+	}
+	for length, count := range other.ChainLengths {
+		s.ChainLengths[length] += count
+	}
+}
+
+// finish is a helper method that computes the averages of s once every
+// element is recorded.
+func (s *HashTableStats) finish() HashTableStats {
+	if s.Buckets > 0 {
+		s.LoadFactor = float64(s.Elements) / float64(s.Buckets)
+	}
+	if s.Elements > 0 {
+		s.AverageProbes = float64(s.totalProbes) / float64(s.Elements)
+	}
+	return *s
+}
+
+// openAddressingClusters is a helper function that records the clusters
+// of the slots for which occupied returns true.
+func openAddressingClusters(stats *HashTableStats, slots int, occupied func(index int) bool) {
+	// the scan starts after a free slot so that a cluster that wraps
+	// around the end of the slots is counted once.
+	start := 0
+	for start < slots && occupied(start) {
+		start++
+	}
+	if start == slots {
+		stats.addChain(slots)
+		return
+	}
+	length := 0
+	for i := 1; i <= slots; i++ {
+		index := (start + i) % slots
+		if occupied(index) {
+			length++
+			continue
+		}
+		stats.EmptyBuckets++
+		stats.addChain(length)
+		length = 0
+	}
+}
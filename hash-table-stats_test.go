@@ -0,0 +1,184 @@
+package datastructures
+
+import (
+	"reflect"
+	"testing"
+)
+
+// constantHasher is a Hasher that returns the same hash for every key, so
+// that every key collides.
+type constantHasher struct{}
+
+func (constantHasher) Hash(key interface{}, seed uint64) (uint64, error) {
+	return 7, nil
+}
+
+func (constantHasher) Equal(a, b interface{}) bool {
+	return DefaultHasher.Equal(a, b)
+}
+
+type statsTable interface {
+	KeyValueTable
+	Stats() HashTableStats
+}
+
+func statsTables(options ...HashTableOption) map[string]statsTable {
+	return map[string]statsTable{
+		"chaining":          NewHashTable(64, options...),
+		"linear probing":    NewLinearProbingHashTable(64, options...),
+		"quadratic probing": NewQuadraticProbingHashTable(64, options...),
+		"double hashing":    NewDoubleHashingHashTable(64, options...),
+		"robin hood":        NewRobinHoodHashTable(64, options...),
+		"concurrent":        NewConcurrentHashTable(64, 4, options...),
+	}
+}
+
+func TestHashTable_Stats(t *testing.T) {
+	for tableName, h := range statsTables(HashTableSeed(5)) {
+		t.Run(tableName, func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				h.Set(i, i)
+			}
+			stats := h.Stats()
+			if stats.Buckets != h.Size() || stats.Elements != h.Elements() {
+				t.Errorf("Stats() buckets, elements = %v, %v, want %v, %v", stats.Buckets, stats.Elements, h.Size(), h.Elements())
+			}
+			if stats.LoadFactor != float64(h.Elements())/float64(h.Size()) {
+				t.Errorf("Stats().LoadFactor = %v", stats.LoadFactor)
+			}
+			chainedElements, longestChain := 0, 0
+			for length, count := range stats.ChainLengths {
+				chainedElements += length * count
+				if length > longestChain {
+					longestChain = length
+				}
+			}
+			if chainedElements != stats.Elements || longestChain != stats.LongestChain {
+				t.Errorf("Stats().ChainLengths = %v, LongestChain = %v", stats.ChainLengths, stats.LongestChain)
+			}
+			if stats.AverageProbes < 1 || stats.AverageProbes > float64(stats.MaxProbes) {
+				t.Errorf("Stats().AverageProbes = %v, MaxProbes = %v", stats.AverageProbes, stats.MaxProbes)
+			}
+		})
+	}
+}
+
+func TestHashTable_Stats_badHasher(t *testing.T) {
+	tests := []struct {
+		name             string
+		table            statsTable
+		wantEmptyBuckets int
+		wantLongestChain int
+		wantMaxProbes    int
+		wantAverage      float64
+	}{
+		{
+			name:             "chaining",
+			table:            NewHashTable(16, HashTableLoadFactors(0, 0), HashTableHasher(constantHasher{})),
+			wantEmptyBuckets: 15,
+			wantLongestChain: 6,
+			wantMaxProbes:    6,
+			wantAverage:      3.5,
+		},
+		{
+			name:             "linear probing",
+			table:            NewLinearProbingHashTable(16, HashTableHasher(constantHasher{})),
+			wantEmptyBuckets: 10,
+			wantLongestChain: 6,
+			wantMaxProbes:    6,
+			wantAverage:      3.5,
+		},
+		{
+			name:             "robin hood",
+			table:            NewRobinHoodHashTable(16, HashTableHasher(constantHasher{})),
+			wantEmptyBuckets: 10,
+			wantLongestChain: 6,
+			wantMaxProbes:    6,
+			wantAverage:      3.5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 6; i++ {
+				tt.table.Set(i, i)
+			}
+			stats := tt.table.Stats()
+			if stats.EmptyBuckets != tt.wantEmptyBuckets {
+				t.Errorf("Stats().EmptyBuckets = %v, want %v", stats.EmptyBuckets, tt.wantEmptyBuckets)
+			}
+			if stats.LongestChain != tt.wantLongestChain {
+				t.Errorf("Stats().LongestChain = %v, want %v", stats.LongestChain, tt.wantLongestChain)
+			}
+			if stats.MaxProbes != tt.wantMaxProbes {
+				t.Errorf("Stats().MaxProbes = %v, want %v", stats.MaxProbes, tt.wantMaxProbes)
+			}
+			if stats.AverageProbes != tt.wantAverage {
+				t.Errorf("Stats().AverageProbes = %v, want %v", stats.AverageProbes, tt.wantAverage)
+			}
+		})
+	}
+}
+
+func TestHashMap_Stats_rehash(t *testing.T) {
+	m := NewHashMap[int, int](2)
+	for i := 0; i < 9; i++ {
+		m.Set(i, i)
+	}
+	if m.oldTable == nil {
+		t.Fatalf("HashMap rehash is not in progress")
+	}
+	stats := m.Stats()
+	if m.oldTable != nil {
+		t.Errorf("HashMap.Stats() did not complete the rehash")
+	}
+	if stats.Elements != 9 || stats.Buckets != m.Size() {
+		t.Errorf("HashMap.Stats() elements, buckets = %v, %v, want %v, %v", stats.Elements, stats.Buckets, 9, m.Size())
+	}
+}
+
+func TestOpenAddressingClusters(t *testing.T) {
+	tests := []struct {
+		name             string
+		occupied         []bool
+		wantEmptyBuckets int
+		wantChainLengths map[int]int
+	}{
+		{
+			name:             "separate clusters",
+			occupied:         []bool{true, false, true, true, false, false},
+			wantEmptyBuckets: 3,
+			wantChainLengths: map[int]int{1: 1, 2: 1},
+		},
+		{
+			name:             "cluster wrapping around",
+			occupied:         []bool{true, true, false, false, true},
+			wantEmptyBuckets: 2,
+			wantChainLengths: map[int]int{3: 1},
+		},
+		{
+			name:             "no free slot",
+			occupied:         []bool{true, true},
+			wantChainLengths: map[int]int{2: 1},
+		},
+		{
+			name:             "no occupied slot",
+			occupied:         []bool{false, false, false},
+			wantEmptyBuckets: 3,
+			wantChainLengths: map[int]int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := newHashTableStats(len(tt.occupied))
+			openAddressingClusters(&stats, len(tt.occupied), func(index int) bool {
+				return tt.occupied[index]
+			})
+			if stats.EmptyBuckets != tt.wantEmptyBuckets {
+				t.Errorf("openAddressingClusters() EmptyBuckets = %v, want %v", stats.EmptyBuckets, tt.wantEmptyBuckets)
+			}
+			if !reflect.DeepEqual(stats.ChainLengths, tt.wantChainLengths) {
+				t.Errorf("openAddressingClusters() ChainLengths = %v, want %v", stats.ChainLengths, tt.wantChainLengths)
+			}
+		})
+	}
+}
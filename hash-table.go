@@ -1,204 +1,172 @@
 package datastructures
 
 import (
+	"errors"
 	"fmt"
-	"math"
-	"reflect"
+)
+
+var (
+	// ErrKeyNotFound is returned when a key is not in a hash table.
+	ErrKeyNotFound = errors.New("key not found")
+	// ErrUnsupportedKey is returned when a key cannot be hashed by the
+	// hasher of a hash table.
+	ErrUnsupportedKey = errors.New("unsupported key")
+)
+
+const (
+	defaultHashTableMinLoadFactor = 0.25
+	defaultHashTableMaxLoadFactor = 1.0
+	// hashTableMinRehashStep is the least number of buckets moved from the
+	// old table to the new table on every operation during a rehash.
+	hashTableMinRehashStep = 2
 )
 
 // HashTable represents a hash table data structure.
 //
-// this hash table data structure implementation uses
-// separate chaining technique to handle collisions.
+// HashTable accepts keys and values of any type, it is a thin wrapper
+// around a HashMap[interface{}, interface{}] (see HashMap for how
+// collisions and resizing are handled).
 type HashTable struct {
-	size          int
-	elementsCount int
-	table         []*DoublyLinkedList
+	hashMap *HashMap[interface{}, interface{}]
 }
 
 // HashTableEntry is the object that represents a hash table entry.
-type HashTableEntry struct {
-	Key   interface{}
-	Value interface{}
+type HashTableEntry = HashMapEntry[interface{}, interface{}]
+
+// hashTableConfig holds the settings shared by the hash tables in this
+// package.
+type hashTableConfig struct {
+	minLoadFactor float64
+	maxLoadFactor float64
+	hasher        Hasher
+	seed          uint64
 }
 
-// NewHashTable returns a new hash table data structure.
-func NewHashTable(size int) *HashTable {
-	ht := &HashTable{
-		size:          size,
-		elementsCount: 0,
-		table:         make([]*DoublyLinkedList, size),
+// HashTableOption configures a hash table created with NewHashTable,
+// NewHashMap or any of the open addressing hash table constructors.
+type HashTableOption func(config *hashTableConfig)
+
+// HashTableLoadFactors sets the load factor thresholds of the hash table.
+//
+// the table doubles its size when the load factor goes above max and halves
+// its size when the load factor goes below min. a max <= 0 disables growing
+// and a min <= 0 disables shrinking.
+//
+// open addressing hash tables only use max, and only when it is between
+// 0 and 1.
+func HashTableLoadFactors(min, max float64) HashTableOption {
+	return func(config *hashTableConfig) {
+		config.minLoadFactor = min
+		config.maxLoadFactor = max
 	}
-	for i := 0; i < size; i++ {
-		ht.table[i] = nil
+}
+
+// HashTableHasher sets the Hasher used to hash and compare the keys of
+// the hash table, DefaultHasher is used by default.
+func HashTableHasher(hasher Hasher) HashTableOption {
+	return func(config *hashTableConfig) {
+		config.hasher = hasher
 	}
-	return ht
 }
 
-// Set sets a new <Key, Value> item in the hash table.
-func (h *HashTable) Set(key interface{}, value interface{}) error {
-	keyHash, err := h.hash(key)
-	if err != nil {
-		return err
+// HashTableNumericKeys sets the policy used by the DefaultHasher to compare
+// numeric keys, it replaces any hasher set with HashTableHasher.
+func HashTableNumericKeys(policy NumericKeyPolicy) HashTableOption {
+	return func(config *hashTableConfig) {
+		config.hasher = NewDefaultHasher(policy)
 	}
-	keyList := h.table[keyHash]
-	if keyList == nil {
-		linkedList := NewDoublyLinkedList()
-		linkedList.Add(&DoublyLinkedListNode{
-			Data: HashTableEntry{Key: key, Value: value},
-		})
-		h.table[keyHash] = linkedList
-		h.elementsCount++
-		return nil
+}
+
+// HashTableSeed sets the seed passed to the Hasher.
+//
+// by default every hash table gets a random seed so that the position of
+// the keys in the table cannot be predicted, a fixed seed should only be
+// used when a deterministic layout is needed (e.g. in tests).
+func HashTableSeed(seed uint64) HashTableOption {
+	return func(config *hashTableConfig) {
+		config.seed = seed
 	}
-	// checking if this key already exist in the hash table.
-	//
-	// if it already exist, the hash table should update the value
-	// in the data table entry.
-	var exist bool
-	h.table[keyHash].Iterate(func(_ int, node *DoublyLinkedListNode) {
-		if node.Data.(HashTableEntry).Key == key {
-			exist = true
-			node.Data = HashTableEntry{Key: key, Value: value}
-		}
-	})
-	if exist {
-		return nil
+}
+
+// newHashTableConfig is a helper function that applies options to the
+// default hash table settings.
+func newHashTableConfig(minLoadFactor, maxLoadFactor float64, options []HashTableOption) hashTableConfig {
+	config := hashTableConfig{
+		minLoadFactor: minLoadFactor,
+		maxLoadFactor: maxLoadFactor,
+		hasher:        DefaultHasher,
+		seed:          randomSeed(),
 	}
+	for _, option := range options {
+		option(&config)
+	}
+	return config
+}
 
-	h.table[keyHash].Add(&DoublyLinkedListNode{
-		Data: HashTableEntry{Key: key, Value: value},
-	})
-	h.elementsCount++
-	return nil
+// NewHashTable returns a new hash table data structure.
+//
+// the table never shrinks below its initial size.
+func NewHashTable(size int, options ...HashTableOption) *HashTable {
+	return &HashTable{
+		hashMap: NewHashMap[interface{}, interface{}](size, options...),
+	}
+}
+
+// Set sets a new <Key, Value> item in the hash table.
+func (h *HashTable) Set(key interface{}, value interface{}) error {
+	return h.hashMap.Set(key, value)
 }
 
 // Get retrieves an item from the hash table using the key.
 func (h *HashTable) Get(key interface{}) (interface{}, error) {
-	keyHash, err := h.hash(key)
+	node, _, err := h.hashMap.lookup(key)
 	if err != nil {
 		return nil, err
 	}
-	linkedList := h.table[keyHash]
-	if linkedList == nil {
-		return nil, fmt.Errorf("no item found for key: %d", key)
+	if node == nil {
+		return nil, fmt.Errorf("%w: %v", ErrKeyNotFound, key)
 	}
-	var value interface{}
-	// this variable is used to check if the key actually exist because a
-	// key can have nil as the value (in this case it will be confusing to test
-	// if the value exist).
-	var exist bool
-	linkedList.Iterate(func(_ int, node *DoublyLinkedListNode) {
-		entry := node.Data.(HashTableEntry)
-		if entry.Key == key {
-			value = entry.Value
-			exist = true
-		}
-	})
-	if exist {
-		return value, nil
-	}
-	return nil, fmt.Errorf("no item found for key: %d", key)
+	return node.Data.(HashTableEntry).Value, nil
 }
 
 // Delete removes an item from the hash table in key position.
 //
 // if there is no item at key position, delete does nothing.
 func (h *HashTable) Delete(key interface{}) {
-	keyHash, err := h.hash(key)
-	if err != nil {
-		return
-	}
-	linkedList := h.table[keyHash]
-	if linkedList == nil {
-		return
-	}
-	newLinkedListNodes := []*DoublyLinkedListNode{}
-	linkedList.Iterate(func(_ int, node *DoublyLinkedListNode) {
-		if node.Data.(HashTableEntry).Key == key {
-			h.elementsCount--
-			return
-		}
-		newLinkedListNodes = append(newLinkedListNodes, node)
-	})
-	linkedList.Clear()
-	for _, node := range newLinkedListNodes {
-		linkedList.Add(node)
-	}
+	h.hashMap.Delete(key)
+}
+
+// Reserve resizes the hash table so that it can hold n elements without
+// growing, the table will not shrink below this size afterwards.
+func (h *HashTable) Reserve(n int) {
+	h.hashMap.Reserve(n)
 }
 
 // Size returns the size of the hash table.
 func (h *HashTable) Size() int {
-	return h.size
+	return h.hashMap.Size()
 }
 
 // Elements returns the number of elements in the hash table.
 func (h *HashTable) Elements() int {
-	return h.elementsCount
-}
-
-// Iterate iterates through the hash table and executes the callback function
-// f for each iteration.
-func (h *HashTable) Iterate(f func(key, value interface{})) {
-	for _, linkedList := range h.table {
-		if linkedList == nil {
-			continue
-		}
-		linkedList.Iterate(func(index int, node *DoublyLinkedListNode) {
-			entry := node.Data.(HashTableEntry)
-			f(entry.Key, entry.Value)
-		})
-	}
+	return h.hashMap.Elements()
 }
 
-// hash is the hash function for hashing keys.
-func (h *HashTable) hash(key interface{}) (int, error) {
-	switch key := key.(type) {
-	case string:
-		return h.hashString(key), nil
-
-	case float64:
-		return h.hashNumber(int(key)), nil
-
-	case float32:
-		return h.hashNumber(int(key)), nil
-
-	case int64:
-		return h.hashNumber(int(key)), nil
-
-	case int32:
-		return h.hashNumber(int(key)), nil
-
-	case int16:
-		return h.hashNumber(int(key)), nil
-
-	case int:
-		return h.hashNumber(key), nil
-
-	default:
-		return 0, fmt.Errorf(
-			"type of: (%v) cannot be used as key, only string, int and floats are accepted",
-			reflect.TypeOf(key),
-		)
-	}
+// Stats returns the bucket chain statistics of the hash table.
+func (h *HashTable) Stats() HashTableStats {
+	return h.hashMap.Stats()
 }
 
-// hashString is a helper function used to hash string key.
-func (h *HashTable) hashString(str string) int {
-	hash := 0
-	for k, s := range str {
-		asciiValue := int(s)
-		if k == 0 {
-			hash += asciiValue + (13 ^ k)
-			continue
-		}
-		hash += asciiValue * (13 ^ k)
-	}
-	return hash % h.size
+// LoadFactor returns the number of elements per bucket in the hash table.
+func (h *HashTable) LoadFactor() float64 {
+	return h.hashMap.LoadFactor()
 }
 
-// hashNumber is a helper function used to hash a number key.
-func (h *HashTable) hashNumber(k int) int {
-	hash := k * 2654435761 % int(math.Pow(2, 32))
-	return hash % h.size
+// Iterate iterates through the hash table and executes the callback function
+// f for each iteration.
+func (h *HashTable) Iterate(f func(key, value interface{})) {
+	h.hashMap.Range(func(key, value interface{}) bool {
+		f(key, value)
+		return true
+	})
 }
@@ -1,7 +1,10 @@
 package datastructures
 
 import (
+	"errors"
+	"math"
 	"reflect"
+	"sort"
 	"testing"
 )
 
@@ -18,42 +21,42 @@ func TestHashTable_hash(t *testing.T) {
 		{
 			name: "'world' string key",
 			args: args{key: "world"},
-			want: 6,
+			want: 5,
 		},
 		{
 			name: "'10' string key",
 			args: args{key: "10"},
-			want: 8,
+			want: 1,
 		},
 		{
 			name: "'a' string key",
 			args: args{key: "a"},
-			want: 0,
+			want: 5,
 		},
 		{
 			name: "'n' string key",
 			args: args{key: "n"},
-			want: 3,
+			want: 1,
 		},
 		{
 			name: "'~~~' string key",
 			args: args{key: "~~~"},
-			want: 1,
+			want: 5,
 		},
 		{
 			name: "'~' string key",
 			args: args{key: "~"},
-			want: 9,
+			want: 8,
 		},
 		{
 			name: "' ' string key",
 			args: args{key: " "},
-			want: 5,
+			want: 4,
 		},
 		{
 			name: "'10' integer key",
 			args: args{key: 10},
-			want: 4,
+			want: 7,
 		},
 		{
 			name: "'0' int key",
@@ -68,38 +71,38 @@ func TestHashTable_hash(t *testing.T) {
 		{
 			name: "'5' float32 key",
 			args: args{key: float32(5)},
-			want: 7,
+			want: 1,
 		},
 		{
 			name: "'2939948995839849223443349204930940493945943094949493034.4455' float64 key",
 			args: args{key: float64(2939948995839849223443349204930940493945943094949493034.4455)},
-			want: 0,
+			want: 7,
 		},
 		{
 			name: "'4448' int16 key",
 			args: args{key: int16(4448)},
-			want: 4,
+			want: 2,
 		},
 		{
 			name: "'239993994' int32 key",
 			args: args{key: int32(239993994)},
-			want: 0,
+			want: 5,
 		},
 		{
 			name: "'399948959896899385993899' int64 key",
 			args: args{key: int64(3999489598968993859)},
-			want: 7,
+			want: 5,
 		},
 		{
 			name:    "invalid key",
-			args:    args{key: struct{}{}},
+			args:    args{key: []int{1}},
 			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			h := NewHashTable(10)
-			got, err := h.hash(tt.args.key)
+			h := NewHashTable(10, HashTableSeed(0))
+			got, err := h.hashMap.hash(tt.args.key)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("HashTable.hash() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -131,22 +134,24 @@ func TestHashTable_Set(t *testing.T) {
 		expectedElements: 3,
 	}
 	t.Run(testCase.name, func(t *testing.T) {
-		h := NewHashTable(2)
+		// resizing is disabled so the items stay in the 2 buckets, the seed
+		// puts "world" and "fish" in bucket 0 and "make" in bucket 1.
+		h := NewHashTable(2, HashTableLoadFactors(0, 0), HashTableSeed(9))
 		for _, item := range testCase.items {
 			h.Set(item.key, item.value)
 		}
 		if h.Elements() != testCase.expectedElements {
 			t.Errorf("HashTable.Set() = %v, want %v", h.Elements(), testCase.expectedElements)
 		}
-		headValue := h.table[0].GetHead().Data.(HashTableEntry).Value
+		headValue := h.hashMap.table[0].GetHead().Data.(HashTableEntry).Value
 		if headValue != "updated world" {
 			t.Errorf("HashTable.Set() = %v, want %v", headValue, "updated world")
 		}
-		secondValue := h.table[1].GetHead().Data.(HashTableEntry).Value
+		secondValue := h.hashMap.table[1].GetHead().Data.(HashTableEntry).Value
 		if secondValue != "make world" {
 			t.Errorf("HashTable.Set() = %v, want %v", secondValue, "make world")
 		}
-		headNextValue := h.table[0].GetHead().Next.Data.(HashTableEntry).Value
+		headNextValue := h.hashMap.table[0].GetHead().Next.Data.(HashTableEntry).Value
 		if headNextValue != "fish world" {
 			t.Errorf("HashTable.Set() = %v, want %v", headNextValue, "fish world")
 		}
@@ -432,9 +437,261 @@ func TestHashTable_Iterate(t *testing.T) {
 			h.Iterate(func(key, value interface{}) {
 				result = append(result, item{key, value})
 			})
+			// the iteration order depends on the random seed of the table.
+			sort.Slice(result, func(i, j int) bool {
+				return result[i].key.(int) < result[j].key.(int)
+			})
 			if !reflect.DeepEqual(tt.items, result) {
 				t.Errorf("HashTable.Iterate() = %v, want %v", tt.items, result)
 			}
 		})
 	}
 }
+
+func TestHashTable_Iterate_delete(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []HashTableOption
+	}{
+		{name: "resizing disabled", options: []HashTableOption{HashTableLoadFactors(0, 0)}},
+		{name: "default load factors"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHashTable(4, tt.options...)
+			for i := 0; i < 16; i++ {
+				h.Set(i, i)
+			}
+			visited := map[interface{}]bool{}
+			h.Iterate(func(key, _ interface{}) {
+				visited[key] = true
+				h.Delete(key)
+			})
+			if len(visited) != 16 {
+				t.Errorf("HashTable.Iterate() visited %v keys, want %v", len(visited), 16)
+			}
+			if h.Elements() != 0 {
+				t.Errorf("HashTable.Elements() = %v, want %v", h.Elements(), 0)
+			}
+		})
+	}
+}
+
+func TestHashTable_resize(t *testing.T) {
+	tests := []struct {
+		name         string
+		size         int
+		options      []HashTableOption
+		setItems     int
+		deleteItems  int
+		expectedSize int
+	}{
+		{
+			name:         "growing with default load factors",
+			size:         2,
+			setItems:     9,
+			expectedSize: 16,
+		},
+		{
+			name:         "growing an empty table",
+			size:         0,
+			setItems:     1,
+			expectedSize: 1,
+		},
+		{
+			name:         "growing disabled",
+			size:         2,
+			options:      []HashTableOption{HashTableLoadFactors(0, 0)},
+			setItems:     9,
+			expectedSize: 2,
+		},
+		{
+			name:         "custom max load factor",
+			size:         2,
+			options:      []HashTableOption{HashTableLoadFactors(0, 4)},
+			setItems:     9,
+			expectedSize: 4,
+		},
+		{
+			name:         "shrinking after deletes",
+			size:         2,
+			setItems:     64,
+			deleteItems:  60,
+			expectedSize: 16,
+		},
+		{
+			name:         "shrinking stops at the initial size",
+			size:         4,
+			setItems:     64,
+			deleteItems:  64,
+			expectedSize: 4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHashTable(tt.size, tt.options...)
+			for i := 0; i < tt.setItems; i++ {
+				h.Set(i, i*10)
+			}
+			for i := 0; i < tt.deleteItems; i++ {
+				h.Delete(i)
+			}
+			if got := h.Size(); got != tt.expectedSize {
+				t.Errorf("HashTable.Size() = %v, want %v", got, tt.expectedSize)
+			}
+			if got := h.Elements(); got != tt.setItems-tt.deleteItems {
+				t.Errorf("HashTable.Elements() = %v, want %v", got, tt.setItems-tt.deleteItems)
+			}
+			// every remaining item must still be reachable while a
+			// rehash might be in progress.
+			for i := tt.deleteItems; i < tt.setItems; i++ {
+				got, err := h.Get(i)
+				if err != nil || got != i*10 {
+					t.Errorf("HashTable.Get(%v) = %v, %v, want %v", i, got, err, i*10)
+				}
+			}
+			count := 0
+			h.Iterate(func(key, value interface{}) {
+				count++
+			})
+			if count != tt.setItems-tt.deleteItems {
+				t.Errorf("HashTable.Iterate() count = %v, want %v", count, tt.setItems-tt.deleteItems)
+			}
+		})
+	}
+}
+
+func TestHashTable_incrementalRehash(t *testing.T) {
+	h := NewHashTable(8, HashTableLoadFactors(0, 0))
+	for i := 0; i < 16; i++ {
+		h.Set(i, i)
+	}
+	h.hashMap.resize(32)
+	if h.hashMap.oldTable == nil {
+		t.Fatalf("HashTable.resize() did not start an incremental rehash")
+	}
+	// updating and deleting items that have not been moved yet.
+	h.Set(15, "updated")
+	h.Delete(14)
+	if got, _ := h.Get(15); got != "updated" {
+		t.Errorf("HashTable.Get() = %v, want %v", got, "updated")
+	}
+	if _, err := h.Get(14); err == nil {
+		t.Errorf("HashTable.Get() error = %v, wantErr %v", err, true)
+	}
+	for i := 0; i < 8 && h.hashMap.oldTable != nil; i++ {
+		h.Get(0)
+	}
+	if h.hashMap.oldTable != nil {
+		t.Errorf("HashTable rehash did not complete")
+	}
+	if got := h.Elements(); got != 15 {
+		t.Errorf("HashTable.Elements() = %v, want %v", got, 15)
+	}
+}
+
+func TestHashTable_Reserve(t *testing.T) {
+	tests := []struct {
+		name         string
+		options      []HashTableOption
+		reserve      int
+		expectedSize int
+	}{
+		{
+			name:         "default load factors",
+			reserve:      100,
+			expectedSize: 100,
+		},
+		{
+			name:         "custom max load factor",
+			options:      []HashTableOption{HashTableLoadFactors(0.1, 0.5)},
+			reserve:      100,
+			expectedSize: 200,
+		},
+		{
+			name:         "reserving less than the size",
+			reserve:      2,
+			expectedSize: 10,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHashTable(10, tt.options...)
+			h.Reserve(tt.reserve)
+			if got := h.Size(); got != tt.expectedSize {
+				t.Errorf("HashTable.Reserve() size = %v, want %v", got, tt.expectedSize)
+			}
+			// the reserved items must not cause a resize.
+			for i := 0; i < tt.reserve; i++ {
+				h.Set(i, i)
+			}
+			if got := h.Size(); got != tt.expectedSize {
+				t.Errorf("HashTable.Size() = %v, want %v", got, tt.expectedSize)
+			}
+		})
+	}
+}
+
+func TestHashTable_floatKeys(t *testing.T) {
+	h := NewHashTable(1024, HashTableLoadFactors(0, 0), HashTableSeed(0))
+	// the keys only differ in their fractional part, they must not be
+	// truncated to the same integer.
+	for i := 0; i < 1024; i++ {
+		h.Set(float64(i)/8, i)
+	}
+	first, _ := h.hashMap.hash(1.5)
+	second, _ := h.hashMap.hash(1.9)
+	if first == second {
+		t.Errorf("HashTable.hash(1.5) = HashTable.hash(1.9) = %v", first)
+	}
+	if got := h.Stats().LongestChain; got > 8 {
+		t.Errorf("HashTable.Stats().LongestChain = %v, want at most %v", got, 8)
+	}
+	for i := 0; i < 1024; i++ {
+		if got, err := h.Get(float64(i) / 8); err != nil || got != i {
+			t.Fatalf("HashTable.Get(%v) = %v, %v, want %v", float64(i)/8, got, err, i)
+		}
+	}
+}
+
+func TestHashTable_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     interface{}
+		wantErr error
+	}{
+		{
+			name:    "missing key",
+			key:     "missing",
+			wantErr: ErrKeyNotFound,
+		},
+		{
+			name:    "struct key with a slice",
+			key:     struct{ s []int }{},
+			wantErr: ErrUnsupportedKey,
+		},
+		{
+			name:    "NaN key",
+			key:     math.NaN(),
+			wantErr: ErrUnsupportedKey,
+		},
+	}
+	for tableName, newTable := range keyValueTables() {
+		for _, tt := range tests {
+			t.Run(tableName+" "+tt.name, func(t *testing.T) {
+				h := newTable(4)
+				h.Set("key", "value")
+				if _, err := h.Get(tt.key); !errors.Is(err, tt.wantErr) {
+					t.Errorf("Get() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			})
+		}
+	}
+
+	t.Run("error message", func(t *testing.T) {
+		_, err := NewHashTable(4).Get("missing")
+		if got := err.Error(); got != "key not found: missing" {
+			t.Errorf("HashTable.Get() error = %v, want %v", got, "key not found: missing")
+		}
+	})
+}
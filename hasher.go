@@ -0,0 +1,316 @@
+package datastructures
+
+import (
+	"bytes"
+	cryptorand "crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+)
+
+const (
+	fnvOffsetBasis = 14695981039346656037
+	fnvPrime       = 1099511628211
+)
+
+// Hasher hashes and compares the keys of a hash table.
+type Hasher interface {
+	// Hash returns the hash of key, seed is the seed of the hash table
+	// the key is stored in.
+	Hash(key interface{}, seed uint64) (uint64, error)
+	// Equal returns true if a and b are the same key.
+	Equal(a, b interface{}) bool
+}
+
+// Hashable is implemented by keys that provide their own hash, it allows
+// structs to be used as keys with the DefaultHasher.
+//
+// a Hashable key must be comparable with == and equal keys must
+// return the same hash.
+type Hashable interface {
+	Hash() uint64
+}
+
+// NumericKeyPolicy decides if numeric keys of different types that hold
+// the same value are the same key.
+type NumericKeyPolicy int
+
+const (
+	// NumericKeysDistinct keeps numeric keys of different types apart,
+	// int(5) and int64(5) are different keys. this is the policy of
+	// the DefaultHasher.
+	NumericKeysDistinct NumericKeyPolicy = iota
+	// NumericKeysNormalized compares numeric keys by value, int(5),
+	// int64(5), uint8(5) and float64(5) are the same key.
+	NumericKeysNormalized
+)
+
+// DefaultHasher is the Hasher used by the hash tables when no hasher
+// is provided, it uses the NumericKeysDistinct policy.
+//
+// it accepts every comparable key and []byte keys. keys that implement
+// Hashable are hashed with their Hash method, strings and byte slices with
+// FNV-1a followed by a murmur3 finalizer and other types like bools, arrays
+// and structs field by field. keys holding NaN are rejected because NaN is
+// not equal to itself, and -0 and +0 are the same key. slices, maps and
+// functions cannot be compared, so keys holding them are rejected.
+var DefaultHasher Hasher = defaultHasher{}
+
+// NewDefaultHasher returns a hasher that works like the DefaultHasher
+// but uses policy to compare numeric keys.
+func NewDefaultHasher(policy NumericKeyPolicy) Hasher {
+	return defaultHasher{normalizeNumbers: policy == NumericKeysNormalized}
+}
+
+type defaultHasher struct {
+	normalizeNumbers bool
+}
+
+// Hash returns the hash of key.
+func (defaultHasher) Hash(key interface{}, seed uint64) (uint64, error) {
+	if number, ok := normalizeNumber(key); ok {
+		if number.kind == nanNumber {
+			return 0, fmt.Errorf("%w: NaN cannot be used as key", ErrUnsupportedKey)
+		}
+		return hashNumber(number, seed), nil
+	}
+	switch key := key.(type) {
+	case string:
+		return hashString(key, seed), nil
+
+	case []byte:
+		return hashBytes(key, seed), nil
+
+	case Hashable:
+		return mixHash(key.Hash() ^ seed), nil
+
+	case nil:
+		return 0, fmt.Errorf("%w: nil cannot be used as key", ErrUnsupportedKey)
+
+	default:
+		return hashValue(reflect.ValueOf(key), seed)
+	}
+}
+
+// hashValue is a helper function that hashes a comparable value with
+// reflection, it hashes the keys that are not numbers, strings, byte
+// slices or Hashable, like bools, named types, arrays and structs.
+func hashValue(v reflect.Value, seed uint64) (uint64, error) {
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			return mixHash(seed ^ 1), nil
+		}
+		return mixHash(seed), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return hashNumber(normalizeInt(v.Int()), seed), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return hashNumber(normalizeUint(v.Uint()), seed), nil
+	case reflect.Float32, reflect.Float64:
+		return hashFloat(v.Float(), seed)
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		hash, err := hashFloat(real(c), seed)
+		if err != nil {
+			return 0, err
+		}
+		return hashFloat(imag(c), hash)
+	case reflect.String:
+		return hashString(v.String(), seed), nil
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		return mixHash(uint64(v.Pointer()) ^ seed), nil
+	case reflect.Interface:
+		if v.IsNil() {
+			return mixHash(seed), nil
+		}
+		return hashValue(v.Elem(), seed)
+	case reflect.Array:
+		hash := seed
+		for i := 0; i < v.Len(); i++ {
+			var err error
+			if hash, err = hashValue(v.Index(i), hash); err != nil {
+				return 0, err
+			}
+		}
+		return mixHash(hash), nil
+	case reflect.Struct:
+		hash := seed
+		for i := 0; i < v.NumField(); i++ {
+			var err error
+			if hash, err = hashValue(v.Field(i), hash); err != nil {
+				return 0, err
+			}
+		}
+		return mixHash(hash), nil
+	default:
+		return 0, fmt.Errorf("%w: type of: (%v) is not comparable", ErrUnsupportedKey, v.Type())
+	}
+}
+
+// hashFloat is a helper function used to hash a float that is part of a
+// key, NaN is rejected.
+func hashFloat(f float64, seed uint64) (uint64, error) {
+	number := normalizeFloat(f)
+	if number.kind == nanNumber {
+		return 0, fmt.Errorf("%w: NaN cannot be used as key", ErrUnsupportedKey)
+	}
+	return hashNumber(number, seed), nil
+}
+
+// Equal returns true if a and b are the same key.
+func (h defaultHasher) Equal(a, b interface{}) bool {
+	if a, ok := a.([]byte); ok {
+		b, ok := b.([]byte)
+		return ok && bytes.Equal(a, b)
+	}
+	if _, ok := b.([]byte); ok {
+		return false
+	}
+	if h.normalizeNumbers {
+		aNumber, aOk := normalizeNumber(a)
+		bNumber, bOk := normalizeNumber(b)
+		if aOk && bOk {
+			return aNumber.kind != nanNumber && aNumber == bNumber
+		}
+	}
+	return a == b
+}
+
+// numberKind is the kind of value held by a normalizedNumber.
+type numberKind uint8
+
+const (
+	intNumber numberKind = iota
+	uintNumber
+	floatNumber
+	nanNumber
+)
+
+// normalizedNumber is the representation of a numeric key that is used to
+// hash and compare it, numeric keys with the same value have the same
+// normalizedNumber whatever their type is.
+type normalizedNumber struct {
+	kind numberKind
+	bits uint64
+}
+
+// normalizeNumber is a helper function that returns the normalizedNumber
+// of key, it returns false if key is not a number.
+func normalizeNumber(key interface{}) (normalizedNumber, bool) {
+	switch key := key.(type) {
+	case int:
+		return normalizeInt(int64(key)), true
+	case int8:
+		return normalizeInt(int64(key)), true
+	case int16:
+		return normalizeInt(int64(key)), true
+	case int32:
+		return normalizeInt(int64(key)), true
+	case int64:
+		return normalizeInt(key), true
+	case uint:
+		return normalizeUint(uint64(key)), true
+	case uint8:
+		return normalizeUint(uint64(key)), true
+	case uint16:
+		return normalizeUint(uint64(key)), true
+	case uint32:
+		return normalizeUint(uint64(key)), true
+	case uint64:
+		return normalizeUint(key), true
+	case uintptr:
+		return normalizeUint(uint64(key)), true
+	case float32:
+		return normalizeFloat(float64(key)), true
+	case float64:
+		return normalizeFloat(key), true
+	default:
+		return normalizedNumber{}, false
+	}
+}
+
+func normalizeInt(v int64) normalizedNumber {
+	return normalizedNumber{kind: intNumber, bits: uint64(v)}
+}
+
+// normalizeUint is a helper function that normalizes an unsigned integer,
+// values that fit in an int64 are normalized as signed integers.
+func normalizeUint(v uint64) normalizedNumber {
+	if v <= math.MaxInt64 {
+		return normalizeInt(int64(v))
+	}
+	return normalizedNumber{kind: uintNumber, bits: v}
+}
+
+// normalizeFloat is a helper function that normalizes a float, floats
+// without a fractional part are normalized as integers when they fit.
+func normalizeFloat(f float64) normalizedNumber {
+	if math.IsNaN(f) {
+		return normalizedNumber{kind: nanNumber}
+	}
+	if f == math.Trunc(f) {
+		// both bounds are powers of two, so they are exact floats.
+		if f >= math.MinInt64 && f < math.MaxInt64 {
+			// -0 is converted to 0 here.
+			return normalizeInt(int64(f))
+		}
+		if f >= 0 && f < math.MaxUint64 {
+			return normalizeUint(uint64(f))
+		}
+	}
+	return normalizedNumber{kind: floatNumber, bits: math.Float64bits(f)}
+}
+
+// hashString is a helper function used to hash string key with
+// seeded FNV-1a.
+func hashString(str string, seed uint64) uint64 {
+	hash := uint64(fnvOffsetBasis) ^ seed
+	for i := 0; i < len(str); i++ {
+		hash ^= uint64(str[i])
+		hash *= fnvPrime
+	}
+	// the lower bits of FNV-1a are weak, they decide the bucket in
+	// tables with a power of two size.
+	return mixHash(hash)
+}
+
+// hashBytes is a helper function used to hash byte slice key with
+// seeded FNV-1a.
+func hashBytes(b []byte, seed uint64) uint64 {
+	hash := uint64(fnvOffsetBasis) ^ seed
+	for _, c := range b {
+		hash ^= uint64(c)
+		hash *= fnvPrime
+	}
+	return mixHash(hash)
+}
+
+// hashNumber is a helper function used to hash a number key.
+func hashNumber(number normalizedNumber, seed uint64) uint64 {
+	// the kind is mixed in so that a float and an integer with the same
+	// bits do not collide.
+	return mixHash((number.bits ^ seed) + uint64(number.kind)*0x9e3779b97f4a7c15)
+}
+
+// mixHash is a helper function that spreads the bits of a hash so that
+// its lower bits can be used directly as an index (murmur3 finalizer).
+func mixHash(hash uint64) uint64 {
+	hash ^= hash >> 33
+	hash *= 0xff51afd7ed558ccd
+	hash ^= hash >> 33
+	hash *= 0xc4ceb9fe1a85ec53
+	hash ^= hash >> 33
+	return hash
+}
+
+// randomSeed returns a random seed for a hash table, so that the
+// hashes of its keys cannot be predicted (hash flooding).
+func randomSeed() uint64 {
+	var b [8]byte
+	if _, err := cryptorand.Read(b[:]); err != nil {
+		return uint64(time.Now().UnixNano())
+	}
+	return binary.LittleEndian.Uint64(b[:])
+}
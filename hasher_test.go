@@ -0,0 +1,340 @@
+package datastructures
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type hashablePoint struct {
+	x, y int
+}
+
+func (p hashablePoint) Hash() uint64 {
+	return uint64(p.x)*31 + uint64(p.y)
+}
+
+// keyName is a named string type, it is hashed with reflection.
+type keyName string
+
+// caseInsensitiveHasher is a Hasher that treats string keys that only
+// differ in case as the same key.
+type caseInsensitiveHasher struct{}
+
+func (caseInsensitiveHasher) Hash(key interface{}, seed uint64) (uint64, error) {
+	return DefaultHasher.Hash(strings.ToLower(key.(string)), seed)
+}
+
+func (caseInsensitiveHasher) Equal(a, b interface{}) bool {
+	return strings.EqualFold(a.(string), b.(string))
+}
+
+func TestDefaultHasher_Hash(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     interface{}
+		sameAs  interface{}
+		wantErr bool
+	}{
+		{
+			name:   "string key",
+			key:    "hello",
+			sameAs: "hello",
+		},
+		{
+			name:   "byte slice key",
+			key:    []byte("hello"),
+			sameAs: []byte("hello"),
+		},
+		{
+			name:   "hashable struct key",
+			key:    hashablePoint{1, 2},
+			sameAs: hashablePoint{1, 2},
+		},
+		{
+			name:   "struct key",
+			key:    struct{ a int }{1},
+			sameAs: struct{ a int }{1},
+		},
+		{
+			name:   "struct key with -0",
+			key:    struct{ X, Y float64 }{math.Copysign(0, -1), 1.5},
+			sameAs: struct{ X, Y float64 }{0, 1.5},
+		},
+		{
+			name:   "struct key with an interface field",
+			key:    struct{ V interface{} }{"a"},
+			sameAs: struct{ V interface{} }{"a"},
+		},
+		{
+			name:   "bool key",
+			key:    true,
+			sameAs: true,
+		},
+		{
+			name:   "array key",
+			key:    [2]string{"a", "b"},
+			sameAs: [2]string{"a", "b"},
+		},
+		{
+			name:   "named string key",
+			key:    keyName("red"),
+			sameAs: keyName("red"),
+		},
+		{
+			name:    "struct key with NaN",
+			key:     struct{ X float64 }{math.NaN()},
+			wantErr: true,
+		},
+		{
+			name:    "struct key with a slice",
+			key:     struct{ V interface{} }{[]int{1}},
+			wantErr: true,
+		},
+		{
+			name:    "int slice key",
+			key:     []int{1},
+			wantErr: true,
+		},
+		{
+			name:    "nil key",
+			key:     nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DefaultHasher.Hash(tt.key, 42)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DefaultHasher.Hash() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			want, _ := DefaultHasher.Hash(tt.sameAs, 42)
+			if got != want {
+				t.Errorf("DefaultHasher.Hash() = %v, want %v", got, want)
+			}
+			other, _ := DefaultHasher.Hash(tt.key, 43)
+			if got == other {
+				t.Errorf("DefaultHasher.Hash() does not depend on the seed")
+			}
+		})
+	}
+}
+
+func TestDefaultHasher_Equal(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b interface{}
+		want bool
+	}{
+		{name: "equal strings", a: "a", b: "a", want: true},
+		{name: "different strings", a: "a", b: "b"},
+		{name: "equal byte slices", a: []byte("a"), b: []byte("a"), want: true},
+		{name: "different byte slices", a: []byte("a"), b: []byte("b")},
+		{name: "byte slice and string", a: []byte("a"), b: "a"},
+		{name: "string and byte slice", a: "a", b: []byte("a")},
+		{name: "equal hashable structs", a: hashablePoint{1, 2}, b: hashablePoint{1, 2}, want: true},
+		{name: "different hashable structs", a: hashablePoint{1, 2}, b: hashablePoint{2, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DefaultHasher.Equal(tt.a, tt.b); got != tt.want {
+				t.Errorf("DefaultHasher.Equal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHashTable_hashableKeys(t *testing.T) {
+	for tableName, newTable := range keyValueTables() {
+		t.Run(tableName, func(t *testing.T) {
+			h := newTable(4)
+			h.Set([]byte("bytes"), 1)
+			h.Set(hashablePoint{1, 2}, 2)
+			h.Set([]byte("bytes"), 3)
+			if h.Elements() != 2 {
+				t.Errorf("Elements() = %v, want %v", h.Elements(), 2)
+			}
+			if got, _ := h.Get([]byte("bytes")); got != 3 {
+				t.Errorf("Get() = %v, want %v", got, 3)
+			}
+			if got, _ := h.Get(hashablePoint{1, 2}); got != 2 {
+				t.Errorf("Get() = %v, want %v", got, 2)
+			}
+			h.Delete([]byte("bytes"))
+			if _, err := h.Get([]byte("bytes")); err == nil {
+				t.Errorf("Get() error = %v, wantErr %v", err, true)
+			}
+		})
+	}
+}
+
+func TestHashTableHasher(t *testing.T) {
+	tables := map[string]KeyValueTable{
+		"chaining":       NewHashTable(4, HashTableHasher(caseInsensitiveHasher{})),
+		"linear probing": NewLinearProbingHashTable(4, HashTableHasher(caseInsensitiveHasher{})),
+		"robin hood":     NewRobinHoodHashTable(4, HashTableHasher(caseInsensitiveHasher{})),
+	}
+	for tableName, h := range tables {
+		t.Run(tableName, func(t *testing.T) {
+			h.Set("Hello", 1)
+			h.Set("HELLO", 2)
+			if h.Elements() != 1 {
+				t.Errorf("Elements() = %v, want %v", h.Elements(), 1)
+			}
+			if got, _ := h.Get("hello"); got != 2 {
+				t.Errorf("Get() = %v, want %v", got, 2)
+			}
+		})
+	}
+}
+
+func TestHashTableSeed(t *testing.T) {
+	first := NewHashTable(1024, HashTableSeed(7))
+	second := NewHashTable(1024, HashTableSeed(7))
+	random := NewHashTable(1024)
+	sameAsRandom := 0
+	for i := 0; i < 100; i++ {
+		key := "key " + strconv.Itoa(i)
+		firstHash, _ := first.hashMap.hash(key)
+		secondHash, _ := second.hashMap.hash(key)
+		randomHash, _ := random.hashMap.hash(key)
+		if firstHash != secondHash {
+			t.Errorf("HashTable.hash() = %v, want %v", secondHash, firstHash)
+		}
+		if firstHash == randomHash {
+			sameAsRandom++
+		}
+	}
+	if sameAsRandom == 100 {
+		t.Errorf("HashTable.hash() with a random seed matches the hash of seed 7")
+	}
+}
+
+func TestHashTable_stringDistribution(t *testing.T) {
+	// similar keys used to collide heavily with the previous string hash.
+	h := NewHashTable(1024, HashTableLoadFactors(0, 0))
+	for i := 0; i < 1024; i++ {
+		h.Set("key"+strconv.Itoa(i), i)
+	}
+	longestChain := h.Stats().LongestChain
+	if longestChain > 10 {
+		t.Errorf("HashTable longest chain = %v, want at most %v", longestChain, 10)
+	}
+}
+
+func TestDefaultHasher_numericKeys(t *testing.T) {
+	tests := []struct {
+		name           string
+		a, b           interface{}
+		wantDistinct   bool
+		wantNormalized bool
+	}{
+		{name: "int and int", a: 5, b: 5, wantDistinct: true, wantNormalized: true},
+		{name: "int and int64", a: 5, b: int64(5), wantNormalized: true},
+		{name: "int and uint8", a: 5, b: uint8(5), wantNormalized: true},
+		{name: "int and float64", a: 5, b: float64(5), wantNormalized: true},
+		{name: "int8 and uint", a: int8(-5), b: uint(5)},
+		{name: "negative int and float32", a: -3, b: float32(-3), wantNormalized: true},
+		{name: "float64 and float32 fraction", a: 0.5, b: float32(0.5), wantNormalized: true},
+		{name: "int and float fraction", a: 5, b: 5.5},
+		{name: "large uint64 and float64", a: uint64(1 << 63), b: float64(1 << 63), wantNormalized: true},
+		{name: "max uint64 and max int64", a: uint64(math.MaxUint64), b: int64(math.MaxInt64)},
+		{name: "negative zero and zero", a: math.Copysign(0, -1), b: 0.0, wantDistinct: true, wantNormalized: true},
+		{name: "negative zero and int zero", a: math.Copysign(0, -1), b: 0, wantNormalized: true},
+		{name: "uintptr and uint16", a: uintptr(7), b: uint16(7), wantNormalized: true},
+		{name: "int and string", a: 5, b: "5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policies := []struct {
+				hasher Hasher
+				want   bool
+			}{
+				{hasher: DefaultHasher, want: tt.wantDistinct},
+				{hasher: NewDefaultHasher(NumericKeysNormalized), want: tt.wantNormalized},
+			}
+			for _, policy := range policies {
+				if got := policy.hasher.Equal(tt.a, tt.b); got != policy.want {
+					t.Errorf("Hasher.Equal(%v, %v) = %v, want %v", tt.a, tt.b, got, policy.want)
+				}
+				if !policy.want {
+					continue
+				}
+				// equal keys must have the same hash.
+				aHash, aErr := policy.hasher.Hash(tt.a, 11)
+				bHash, bErr := policy.hasher.Hash(tt.b, 11)
+				if aErr != nil || bErr != nil || aHash != bHash {
+					t.Errorf("Hasher.Hash() = %v (%v), %v (%v), want equal hashes", aHash, aErr, bHash, bErr)
+				}
+			}
+		})
+	}
+}
+
+func TestHashTableNumericKeys(t *testing.T) {
+	tests := []struct {
+		name         string
+		policy       NumericKeyPolicy
+		wantElements int
+		wantValue    interface{}
+	}{
+		{
+			name:         "distinct numeric keys",
+			policy:       NumericKeysDistinct,
+			wantElements: 4,
+			wantValue:    "int",
+		},
+		{
+			name:         "normalized numeric keys",
+			policy:       NumericKeysNormalized,
+			wantElements: 1,
+			wantValue:    "float64",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHashTable(4, HashTableNumericKeys(tt.policy))
+			h.Set(5, "int")
+			h.Set(int64(5), "int64")
+			h.Set(uint32(5), "uint32")
+			h.Set(5.0, "float64")
+			if h.Elements() != tt.wantElements {
+				t.Errorf("HashTable.Elements() = %v, want %v", h.Elements(), tt.wantElements)
+			}
+			if got, _ := h.Get(5); got != tt.wantValue {
+				t.Errorf("HashTable.Get() = %v, want %v", got, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestHashTable_negativeAndUnsignedKeys(t *testing.T) {
+	keys := []interface{}{
+		-1, int8(-128), int16(-300), int32(math.MinInt32), int64(math.MinInt64),
+		uint(1), uint8(255), uint16(65535), uint32(math.MaxUint32), uint64(math.MaxUint64), uintptr(9),
+		-2.5, float32(-0.25), math.Inf(1), math.Inf(-1),
+	}
+	for tableName, newTable := range keyValueTables() {
+		t.Run(tableName, func(t *testing.T) {
+			h := newTable(3)
+			for i, key := range keys {
+				if err := h.Set(key, i); err != nil {
+					t.Fatalf("Set(%v) error = %v", key, err)
+				}
+			}
+			for i, key := range keys {
+				if got, err := h.Get(key); err != nil || got != i {
+					t.Errorf("Get(%v) = %v, %v, want %v", key, got, err, i)
+				}
+			}
+			h.Set(math.Copysign(0, -1), "negative zero")
+			if got, _ := h.Get(0.0); got != "negative zero" {
+				t.Errorf("Get(0.0) = %v, want %v", got, "negative zero")
+			}
+		})
+	}
+}
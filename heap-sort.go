@@ -0,0 +1,53 @@
+package datastructures
+
+import "cmp"
+
+// HeapSort sorts items in place in ascending order, or in descending order
+// if descending is true.
+//
+// time complexity: O(n log n), it is not stable.
+func HeapSort[T cmp.Ordered](items []T, descending bool) {
+	if descending {
+		HeapSortFunc(items, func(a, b T) bool {
+			return cmp.Less(b, a)
+		})
+		return
+	}
+	HeapSortFunc(items, cmp.Less[T])
+}
+
+// HeapSortFunc sorts items in place in the order defined by less.
+func HeapSortFunc[T any](items []T, less func(a, b T) bool) {
+	// items is turned into a heap whose root is the last item in order,
+	// the root is then swapped with the end of the unsorted part.
+	greater := func(a, b T) bool {
+		return less(b, a)
+	}
+	for index := len(items)/2 - 1; index >= 0; index-- {
+		heapSortSiftDown(items, index, len(items), greater)
+	}
+	for end := len(items) - 1; end > 0; end-- {
+		items[0], items[end] = items[end], items[0]
+		heapSortSiftDown(items, 0, end, greater)
+	}
+}
+
+// heapSortSiftDown moves the item at index down in the heap made of the
+// first length items.
+func heapSortSiftDown[T any](items []T, index, length int, less func(a, b T) bool) {
+	for {
+		smallest := index
+		leftChildIndex, rightChildIndex := 2*index+1, 2*index+2
+		if leftChildIndex < length && less(items[leftChildIndex], items[smallest]) {
+			smallest = leftChildIndex
+		}
+		if rightChildIndex < length && less(items[rightChildIndex], items[smallest]) {
+			smallest = rightChildIndex
+		}
+		if smallest == index {
+			return
+		}
+		items[index], items[smallest] = items[smallest], items[index]
+		index = smallest
+	}
+}
@@ -0,0 +1,80 @@
+package datastructures
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestHeapSort(t *testing.T) {
+	tests := []struct {
+		name       string
+		items      []int
+		descending bool
+		want       []int
+	}{
+		{
+			name:  "ascending",
+			items: []int{5, 2, 9, 1, 5, 6, -3},
+			want:  []int{-3, 1, 2, 5, 5, 6, 9},
+		},
+		{
+			name:       "descending",
+			items:      []int{5, 2, 9, 1, 5, 6, -3},
+			descending: true,
+			want:       []int{9, 6, 5, 5, 2, 1, -3},
+		},
+		{
+			name:  "sorted items",
+			items: []int{1, 2, 3, 4},
+			want:  []int{1, 2, 3, 4},
+		},
+		{
+			name:  "one item",
+			items: []int{7},
+			want:  []int{7},
+		},
+		{
+			name:  "empty slice",
+			items: []int{},
+			want:  []int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			HeapSort(tt.items, tt.descending)
+			if !reflect.DeepEqual(tt.items, tt.want) {
+				t.Errorf("HeapSort() = %v, want %v", tt.items, tt.want)
+			}
+		})
+	}
+}
+
+func TestHeapSort_random(t *testing.T) {
+	random := rand.New(rand.NewSource(3))
+	for size := 0; size < 200; size++ {
+		items := make([]float64, size)
+		for i := range items {
+			items[i] = float64(random.Intn(50))
+		}
+		want := append([]float64{}, items...)
+		sort.Float64s(want)
+		HeapSort(items, false)
+		if !reflect.DeepEqual(items, want) {
+			t.Fatalf("HeapSort() = %v, want %v", items, want)
+		}
+	}
+}
+
+func TestHeapSortFunc(t *testing.T) {
+	items := []string{"banana", "Cherry", "apple", "date"}
+	HeapSortFunc(items, func(a, b string) bool {
+		return strings.ToLower(a) < strings.ToLower(b)
+	})
+	want := []string{"apple", "banana", "Cherry", "date"}
+	if !reflect.DeepEqual(items, want) {
+		t.Errorf("HeapSortFunc() = %v, want %v", items, want)
+	}
+}
@@ -0,0 +1,450 @@
+package datastructures
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+// heapHandle tracks the position of one element of a heap, it is the
+// HeapHandle returned by Push.
+type heapHandle[T comparable] struct {
+	// heap is the heap of the element, it is nil once the element is
+	// removed.
+	heap *Heap[T]
+	// index is the position of the element in the heap items.
+	index int
+	// group holds the handles of the items equal to the item of the
+	// element, it is used instead of a lookup by item so that items that
+	// are not equal to themselves, like NaN, can be removed.
+	group *heapHandleGroup[T]
+	// slot is the position of the handle in the handles of group.
+	slot int
+	// item is the item of the element once it is removed.
+	item T
+}
+
+// heapHandleGroup holds the handles of equal items.
+type heapHandleGroup[T comparable] struct {
+	handles []*heapHandle[T]
+}
+
+// Item returns the item of the element, or the item it had when it was
+// removed.
+func (handle *heapHandle[T]) Item() T {
+	if handle.heap == nil {
+		return handle.item
+	}
+	return handle.heap.items[handle.index]
+}
+
+// heapConfig holds the settings of a heap.
+type heapConfig struct {
+	arity int
+}
+
+// HeapOption configures a heap created with NewHeap or any of the heap
+// constructors.
+type HeapOption func(config *heapConfig)
+
+// HeapArity sets the number of children of every element, 2 by default.
+//
+// a larger arity makes the heap shallower: Insert compares fewer elements
+// and the children of an element are closer in memory, but Poll compares
+// more children at every level. an arity below 2 is ignored.
+func HeapArity(arity int) HeapOption {
+	return func(config *heapConfig) {
+		if arity >= 2 {
+			config.arity = arity
+		}
+	}
+}
+
+// newHeapConfig returns the heap settings with options applied.
+func newHeapConfig(options []HeapOption) heapConfig {
+	config := heapConfig{arity: 2}
+	for _, option := range options {
+		option(&config)
+	}
+	return config
+}
+
+// Heap represents a d-ary heap data structure ordered by a less function,
+// the root is the item that is less than every other item. it is a binary
+// heap unless the HeapArity option is used.
+//
+// every element has a handle holding its position, the handles of equal
+// items are stored together so that updating a position is O(1) however
+// many duplicates the heap has.
+type Heap[T comparable] struct {
+	// positions holds the handles of every item, it has no entry for
+	// items that are not in the heap or not equal to themselves.
+	positions map[T]*heapHandleGroup[T]
+	items     []T
+	// handles holds the handle of the element at the same index of items.
+	handles []*heapHandle[T]
+	length  int
+	less    func(a, b T) bool
+	// arity is the number of children of every element.
+	arity int
+}
+
+// NewHeap returns a new heap data structure ordered by less.
+func NewHeap[T comparable](less func(a, b T) bool, options ...HeapOption) *Heap[T] {
+	return &Heap[T]{
+		positions: make(map[T]*heapHandleGroup[T]),
+		less:      less,
+		arity:     newHeapConfig(options).arity,
+	}
+}
+
+// NewHeapFromSlice returns a new heap data structure ordered by less that
+// holds a copy of items.
+//
+// the heap is built bottom-up in O(n), which is faster than inserting the
+// items one by one.
+func NewHeapFromSlice[T comparable](items []T, less func(a, b T) bool, options ...HeapOption) *Heap[T] {
+	h := &Heap[T]{
+		positions: make(map[T]*heapHandleGroup[T], len(items)),
+		items:     make([]T, len(items)),
+		handles:   make([]*heapHandle[T], len(items)),
+		length:    len(items),
+		less:      less,
+		arity:     newHeapConfig(options).arity,
+	}
+	copy(h.items, items)
+	// the handles are allocated together rather than one by one.
+	handles := make([]heapHandle[T], len(items))
+	for index, item := range items {
+		handle := &handles[index]
+		handle.heap, handle.index = h, index
+		h.addHandle(item, handle)
+		h.handles[index] = handle
+	}
+	h.heapify()
+	return h
+}
+
+// Insert adds a new item to the heap.
+func (h *Heap[T]) Insert(item T) *Heap[T] {
+	h.add(item)
+	h.siftUp(h.length - 1)
+	return h
+}
+
+// Push adds a new item to the heap and returns its handle.
+func (h *Heap[T]) Push(item T) HeapHandle[T] {
+	handle := h.add(item)
+	h.siftUp(h.length - 1)
+	return handle
+}
+
+// Merge adds the items of other to the heap, other is not modified.
+//
+// a few items are inserted one by one, otherwise the heap is rebuilt
+// bottom-up in O(n + m).
+func (h *Heap[T]) Merge(other *Heap[T]) *Heap[T] {
+	length := h.length
+	for _, item := range other.items {
+		h.add(item)
+	}
+	h.restore(length)
+	return h
+}
+
+// Meld moves the items of other, which must be a *Heap[T], to the heap.
+//
+// unlike Merge, other is emptied and the handles of its items now belong
+// to the heap.
+func (h *Heap[T]) Meld(other PriorityQueue[T]) error {
+	o, ok := other.(*Heap[T])
+	if !ok {
+		return fmt.Errorf("%w: %T and %T", ErrIncompatibleHeap, h, other)
+	}
+	if o == h {
+		return nil
+	}
+	length := h.length
+	for index, item := range o.items {
+		handle := o.handles[index]
+		handle.heap, handle.index = h, h.length
+		h.addHandle(item, handle)
+		h.items = append(h.items, item)
+		h.handles = append(h.handles, handle)
+		h.length++
+	}
+	*o = *NewHeap(o.less, HeapArity(o.arity))
+	h.restore(length)
+	return nil
+}
+
+// add appends item at the end of the heap without moving it.
+func (h *Heap[T]) add(item T) *heapHandle[T] {
+	handle := &heapHandle[T]{heap: h, index: h.length}
+	h.addHandle(item, handle)
+	h.items = append(h.items, item)
+	h.handles = append(h.handles, handle)
+	h.length++
+	return handle
+}
+
+// restore restores the heap property after elements were appended from
+// index length, a few elements are moved up one by one, otherwise the heap
+// is rebuilt.
+func (h *Heap[T]) restore(length int) {
+	added := h.length - length
+	if added*bits.Len(uint(h.length)) < h.length {
+		for index := length; index < h.length; index++ {
+			h.siftUp(index)
+		}
+		return
+	}
+	h.heapify()
+}
+
+// heapify restores the heap property of all the elements, every element
+// that has children is moved down starting from the last one.
+func (h *Heap[T]) heapify() {
+	for index := (h.length - 2) / h.arity; index >= 0; index-- {
+		h.siftDown(index)
+	}
+}
+
+// Poll removes the root element from the heap.
+func (h *Heap[T]) Poll() (T, error) {
+	return h.removeAtPosition(0)
+}
+
+// Min returns the root item of the heap, it is the same as Peek.
+func (h *Heap[T]) Min() (T, error) {
+	return h.Peek()
+}
+
+// ExtractMin removes the root item of the heap, it is the same as Poll.
+func (h *Heap[T]) ExtractMin() (T, error) {
+	return h.Poll()
+}
+
+// DecreaseKey replaces the item of handle with item, which must not be
+// greater than the current item.
+func (h *Heap[T]) DecreaseKey(handle HeapHandle[T], item T) error {
+	element, err := h.handle(handle)
+	if err != nil {
+		return err
+	}
+	current := h.items[element.index]
+	if h.less(current, item) {
+		return fmt.Errorf("%w: %v is greater than %v", ErrInvalidDecrease, item, current)
+	}
+	if current == item {
+		return nil
+	}
+	h.removeHandle(current, element)
+	h.addHandle(item, element)
+	h.items[element.index] = item
+	h.siftUp(element.index)
+	return nil
+}
+
+// Delete removes the item of handle from the heap.
+func (h *Heap[T]) Delete(handle HeapHandle[T]) error {
+	element, err := h.handle(handle)
+	if err != nil {
+		return err
+	}
+	_, err = h.removeAtPosition(element.index)
+	return err
+}
+
+// handle returns handle as a handle of the heap.
+func (h *Heap[T]) handle(handle HeapHandle[T]) (*heapHandle[T], error) {
+	element, ok := handle.(*heapHandle[T])
+	if !ok || element.heap != h {
+		return nil, ErrInvalidHandle
+	}
+	return element, nil
+}
+
+// removeAtPosition removes an element from the specified position.
+//
+// the last element takes its place and is moved down or up, it can be
+// smaller than the parent of the position when the position is not the
+// root.
+func (h *Heap[T]) removeAtPosition(position int) (T, error) {
+	if h.length == 0 {
+		var zero T
+		return zero, errors.New("heap is empty")
+	}
+	itemAtPos := h.items[position]
+	h.removeHandle(itemAtPos, h.handles[position])
+	h.handles[position].heap = nil
+	h.handles[position].item = itemAtPos
+	last := h.length - 1
+	if position != last {
+		h.items[position] = h.items[last]
+		h.handles[position] = h.handles[last]
+		h.handles[position].index = position
+	}
+	h.handles[last] = nil
+	h.items = h.items[:last]
+	h.handles = h.handles[:last]
+	h.length--
+	if h.length == 0 {
+		h.items = nil
+		h.handles = nil
+	}
+	if position != last && !h.siftDown(position) {
+		h.siftUp(position)
+	}
+	return itemAtPos, nil
+}
+
+// addHandle adds the handle of an element to the handles of item.
+//
+// an item that is not equal to itself gets a group of its own that is not
+// in positions, a map lookup would never find it.
+func (h *Heap[T]) addHandle(item T, handle *heapHandle[T]) {
+	group, ok := h.positions[item]
+	if !ok {
+		group = &heapHandleGroup[T]{}
+		if item == item {
+			h.positions[item] = group
+		}
+	}
+	handle.group, handle.slot = group, len(group.handles)
+	group.handles = append(group.handles, handle)
+}
+
+// removeHandle removes the handle of an element from the handles of item,
+// the last handle of item takes its slot.
+func (h *Heap[T]) removeHandle(item T, handle *heapHandle[T]) {
+	handles := handle.group.handles
+	last := len(handles) - 1
+	handles[handle.slot] = handles[last]
+	handles[handle.slot].slot = handle.slot
+	handles[last] = nil
+	handle.group.handles = handles[:last]
+	if last == 0 && item == item {
+		delete(h.positions, item)
+	}
+	handle.group = nil
+}
+
+// siftUp moves the element at index up until its parent is not greater
+// than it.
+func (h *Heap[T]) siftUp(index int) {
+	for index > 0 {
+		parentIndex := (index - 1) / h.arity
+		if !h.less(h.items[index], h.items[parentIndex]) {
+			return
+		}
+		h.swap(parentIndex, index)
+		index = parentIndex
+	}
+}
+
+// siftDown moves the element at index down until none of its children is
+// less than it, it returns true if the element moved.
+func (h *Heap[T]) siftDown(index int) bool {
+	start := index
+	for {
+		smallest := index
+		firstChildIndex := h.arity*index + 1
+		lastChildIndex := min(firstChildIndex+h.arity, h.length)
+		for childIndex := firstChildIndex; childIndex < lastChildIndex; childIndex++ {
+			if h.less(h.items[childIndex], h.items[smallest]) {
+				smallest = childIndex
+			}
+		}
+		if smallest == index {
+			return index != start
+		}
+		h.swap(index, smallest)
+		index = smallest
+	}
+}
+
+// swap swaps two elements and updates their handles.
+func (h *Heap[T]) swap(index1, index2 int) {
+	h.items[index1], h.items[index2] = h.items[index2], h.items[index1]
+	h.handles[index1], h.handles[index2] = h.handles[index2], h.handles[index1]
+	h.handles[index1].index = index1
+	h.handles[index2].index = index2
+}
+
+// Validate checks that every element of the heap is not less than its
+// parent and that the handles hold the position of every element.
+func (h *Heap[T]) Validate() error {
+	if h.length != len(h.items) || h.length != len(h.handles) {
+		return fmt.Errorf("heap length is %d, but it has %d items and %d handles", h.length, len(h.items), len(h.handles))
+	}
+	for index := 1; index < h.length; index++ {
+		parentIndex := (index - 1) / h.arity
+		if h.less(h.items[index], h.items[parentIndex]) {
+			return fmt.Errorf(
+				"item %v at %d is less than its parent %v at %d",
+				h.items[index], index, h.items[parentIndex], parentIndex,
+			)
+		}
+	}
+	for index, handle := range h.handles {
+		item := h.items[index]
+		if handle.heap != h || handle.index != index || handle.group == nil ||
+			handle.slot >= len(handle.group.handles) || handle.group.handles[handle.slot] != handle {
+			return fmt.Errorf("handle %v of item %v at %d has a wrong position", *handle, item, index)
+		}
+		if item == item && h.positions[item] != handle.group {
+			return fmt.Errorf("handle of item %v at %d is not in the handles of the item", item, index)
+		}
+	}
+	for item, group := range h.positions {
+		if len(group.handles) == 0 {
+			return fmt.Errorf("item %v has no handles", item)
+		}
+		for _, handle := range group.handles {
+			if handle.heap != h || h.items[handle.index] != item {
+				return fmt.Errorf("handle %v of item %v has a wrong position", *handle, item)
+			}
+		}
+	}
+	return nil
+}
+
+// Remove removes an item from the heap.
+func (h *Heap[T]) Remove(item T) (T, error) {
+	group, ok := h.positions[item]
+	if !ok {
+		var zero T
+		return zero, fmt.Errorf("%v is not in heap", item)
+	}
+	// remove the element of the last handle, it is removed from the
+	// handles without moving the others.
+	return h.removeAtPosition(group.handles[len(group.handles)-1].index)
+}
+
+// Contains returns true if the item is in the heap, else false.
+func (h *Heap[T]) Contains(item T) bool {
+	_, ok := h.positions[item]
+	return ok
+}
+
+// Size returns the size of the heap.
+func (h *Heap[T]) Size() int {
+	return h.length
+}
+
+// Peek returns the root item of the heap without removing it.
+func (h *Heap[T]) Peek() (T, error) {
+	if h.Size() == 0 {
+		var zero T
+		return zero, errors.New("heap is empty")
+	}
+	return h.items[0], nil
+}
+
+// GetList returns the heap items as a list.
+//
+// time complexity: 0(1)
+func (h *Heap[T]) GetList() []T {
+	return h.items
+}
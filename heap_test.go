@@ -0,0 +1,432 @@
+package datastructures
+
+import (
+	"container/heap"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// heapPositions is a test helper that returns the sorted positions of every
+// item of the heap.
+func heapPositions[T comparable](h *Heap[T]) map[T][]int {
+	positions := make(map[T][]int)
+	for item, group := range h.positions {
+		for _, handle := range group.handles {
+			positions[item] = append(positions[item], handle.index)
+		}
+		sort.Ints(positions[item])
+	}
+	return positions
+}
+
+// heapPollAll is a test helper that polls every item of the heap.
+func heapPollAll[T comparable](h *Heap[T]) []T {
+	items := []T{}
+	for h.Size() > 0 {
+		item, _ := h.Poll()
+		items = append(items, item)
+	}
+	return items
+}
+
+func TestHeap_orderings(t *testing.T) {
+	tests := []struct {
+		name  string
+		heap  *Heap[string]
+		items []string
+		want  []string
+	}{
+		{
+			name:  "min heap",
+			heap:  NewMinHeapOf[string](),
+			items: []string{"pear", "apple", "fig", "banana"},
+			want:  []string{"apple", "banana", "fig", "pear"},
+		},
+		{
+			name:  "max heap",
+			heap:  NewMaxHeapOf[string](),
+			items: []string{"pear", "apple", "fig", "banana"},
+			want:  []string{"pear", "fig", "banana", "apple"},
+		},
+		{
+			name: "custom ordering",
+			heap: NewHeap(func(a, b string) bool {
+				return len(a) < len(b)
+			}),
+			items: []string{"banana", "fig", "kiwi", "apple"},
+			want:  []string{"fig", "kiwi", "apple", "banana"},
+		},
+		{
+			name: "case insensitive ordering",
+			heap: NewHeap(func(a, b string) bool {
+				return strings.ToLower(a) < strings.ToLower(b)
+			}),
+			items: []string{"b", "C", "a"},
+			want:  []string{"a", "b", "C"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, item := range tt.items {
+				tt.heap.Insert(item)
+			}
+			if got := heapPollAll(tt.heap); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Heap.Poll() order = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHeap_structs(t *testing.T) {
+	type job struct {
+		name     string
+		deadline time.Time
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	report := job{name: "report", deadline: start.Add(3 * time.Hour)}
+	backup := job{name: "backup", deadline: start.Add(time.Hour)}
+	deploy := job{name: "deploy", deadline: start.Add(2 * time.Hour)}
+
+	h := NewHeap(func(a, b job) bool {
+		return a.deadline.Before(b.deadline)
+	})
+	if _, err := h.Peek(); err == nil {
+		t.Errorf("Heap.Peek() error = nil on an empty heap")
+	}
+	h.Insert(report).Insert(backup).Insert(deploy)
+	if got, _ := h.Peek(); got != backup {
+		t.Errorf("Heap.Peek() = %v, want %v", got, backup)
+	}
+	if !h.Contains(deploy) {
+		t.Errorf("Heap.Contains(%v) = false, want true", deploy)
+	}
+	if removed, err := h.Remove(deploy); err != nil || removed != deploy {
+		t.Errorf("Heap.Remove() = %v, %v, want %v", removed, err, deploy)
+	}
+	if _, err := h.Remove(deploy); err == nil {
+		t.Errorf("Heap.Remove() error = nil for a removed item")
+	}
+	want := []job{backup, report}
+	if got := heapPollAll(h); !reflect.DeepEqual(got, want) {
+		t.Errorf("Heap.Poll() order = %v, want %v", got, want)
+	}
+}
+
+func TestHeap_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		corrupt func(h *Heap[int])
+		wantErr bool
+	}{
+		{
+			name: "valid heap",
+		},
+		{
+			name: "item less than its parent",
+			corrupt: func(h *Heap[int]) {
+				h.items[0], h.items[1] = h.items[1], h.items[0]
+			},
+			wantErr: true,
+		},
+		{
+			name: "wrong handle index",
+			corrupt: func(h *Heap[int]) {
+				h.positions[1].handles[0].index = 3
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing handle",
+			corrupt: func(h *Heap[int]) {
+				delete(h.positions, 5)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewMinHeapOf[int]()
+			for _, item := range []int{5, 3, 8, 1, 9, 2} {
+				h.Insert(item)
+			}
+			if tt.corrupt != nil {
+				tt.corrupt(h)
+			}
+			if err := h.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Heap.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// referenceHeap is a heap.Interface implementation used to check Heap.
+type referenceHeap struct {
+	items []int
+	less  func(a, b int) bool
+}
+
+func (r *referenceHeap) Len() int           { return len(r.items) }
+func (r *referenceHeap) Less(i, j int) bool { return r.less(r.items[i], r.items[j]) }
+func (r *referenceHeap) Swap(i, j int)      { r.items[i], r.items[j] = r.items[j], r.items[i] }
+func (r *referenceHeap) Push(x any)         { r.items = append(r.items, x.(int)) }
+func (r *referenceHeap) Pop() any {
+	item := r.items[len(r.items)-1]
+	r.items = r.items[:len(r.items)-1]
+	return item
+}
+
+func TestHeap_differential(t *testing.T) {
+	tests := []struct {
+		name  string
+		less  func(a, b int) bool
+		arity int
+	}{
+		{name: "min heap", less: func(a, b int) bool { return a < b }, arity: 2},
+		{name: "max heap", less: func(a, b int) bool { return a > b }, arity: 2},
+		{name: "ternary min heap", less: func(a, b int) bool { return a < b }, arity: 3},
+		{name: "4-ary max heap", less: func(a, b int) bool { return a > b }, arity: 4},
+		{name: "8-ary min heap", less: func(a, b int) bool { return a < b }, arity: 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			random := rand.New(rand.NewSource(7))
+			h := NewHeap(tt.less, HeapArity(tt.arity))
+			reference := &referenceHeap{less: tt.less}
+			for i := 0; i < 5000; i++ {
+				switch operation := random.Intn(5); {
+				case operation < 2:
+					// a small range of items makes duplicates common.
+					item := random.Intn(40)
+					h.Insert(item)
+					heap.Push(reference, item)
+				case operation < 4:
+					got, err := h.Poll()
+					if reference.Len() == 0 {
+						if err == nil {
+							t.Fatalf("Heap.Poll() error = nil on an empty heap")
+						}
+						continue
+					}
+					if want := heap.Pop(reference).(int); err != nil || got != want {
+						t.Fatalf("Heap.Poll() = %v, %v, want %v", got, err, want)
+					}
+				default:
+					item := random.Intn(40)
+					index := -1
+					for j, referenceItem := range reference.items {
+						if referenceItem == item {
+							index = j
+							break
+						}
+					}
+					if _, err := h.Remove(item); (err == nil) != (index != -1) {
+						t.Fatalf("Heap.Remove(%v) error = %v, item in heap = %v", item, err, index != -1)
+					}
+					if index != -1 {
+						heap.Remove(reference, index)
+					}
+				}
+				if h.Size() != reference.Len() {
+					t.Fatalf("Heap.Size() = %v, want %v", h.Size(), reference.Len())
+				}
+				if err := h.Validate(); err != nil {
+					t.Fatalf("Heap.Validate() error = %v after %v operations", err, i+1)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkHeap_duplicates(b *testing.B) {
+	for _, distinct := range []int{1, 16, 1 << 20} {
+		b.Run("distinct items "+strconv.Itoa(distinct), func(b *testing.B) {
+			random := rand.New(rand.NewSource(1))
+			items := make([]int, 10000)
+			for i := range items {
+				items[i] = random.Intn(distinct)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				h := NewMinHeapOf[int]()
+				for _, item := range items {
+					h.Insert(item)
+				}
+				for _, item := range items[:len(items)/2] {
+					h.Remove(item)
+				}
+				for h.Size() > 0 {
+					h.Poll()
+				}
+			}
+		})
+	}
+}
+
+func TestNewHeapFromSlice(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []int
+		less  func(a, b int) bool
+		want  []int
+	}{
+		{
+			name:  "min heap",
+			items: []int{5, 3, 9, 1, 3, 7, 2},
+			less:  func(a, b int) bool { return a < b },
+			want:  []int{1, 2, 3, 3, 5, 7, 9},
+		},
+		{
+			name:  "max heap",
+			items: []int{5, 3, 9, 1, 3, 7, 2},
+			less:  func(a, b int) bool { return a > b },
+			want:  []int{9, 7, 5, 3, 3, 2, 1},
+		},
+		{
+			name:  "empty slice",
+			items: []int{},
+			less:  func(a, b int) bool { return a < b },
+			want:  []int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := append([]int{}, tt.items...)
+			h := NewHeapFromSlice(input, tt.less)
+			if err := h.Validate(); err != nil {
+				t.Fatalf("Heap.Validate() error = %v", err)
+			}
+			if got := heapPollAll(h); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Heap.Poll() order = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(input, tt.items) {
+				t.Errorf("NewHeapFromSlice() modified its input to %v", input)
+			}
+		})
+	}
+}
+
+func TestHeap_Merge(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []int
+		other []int
+	}{
+		{name: "merging a few items", items: []int{8, 3, 10, 1, 6, 4, 7, 12, 14, 2}, other: []int{5, 0}},
+		{name: "merging many items", items: []int{8, 3, 6}, other: []int{5, 0, 9, 3, 11, 2, 4}},
+		{name: "merging an empty heap", items: []int{2, 1}},
+		{name: "merging into an empty heap", other: []int{2, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHeapFromSlice(tt.items, func(a, b int) bool { return a < b })
+			other := NewHeapFromSlice(tt.other, func(a, b int) bool { return a < b })
+			h.Merge(other)
+			if err := h.Validate(); err != nil {
+				t.Fatalf("Heap.Validate() error = %v", err)
+			}
+			if other.Size() != len(tt.other) {
+				t.Errorf("Heap.Merge() changed the size of other to %v", other.Size())
+			}
+			want := append(append([]int{}, tt.items...), tt.other...)
+			sort.Ints(want)
+			if got := heapPollAll(h); !reflect.DeepEqual(got, want) {
+				t.Errorf("Heap.Poll() order = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func BenchmarkNewHeapFromSlice(b *testing.B) {
+	random := rand.New(rand.NewSource(1))
+	items := make([]int, 1000000)
+	for i := range items {
+		items[i] = random.Int()
+	}
+	b.Run("heapify", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			NewHeapFromSlice(items, func(a, b int) bool { return a < b })
+		}
+	})
+	b.Run("insert", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			h := NewMinHeapOf[int]()
+			for _, item := range items {
+				h.Insert(item)
+			}
+		}
+	})
+}
+
+func TestHeapArity(t *testing.T) {
+	tests := []struct {
+		name      string
+		arity     int
+		wantArity int
+	}{
+		{name: "default arity", wantArity: 2},
+		{name: "4-ary heap", arity: 4, wantArity: 4},
+		{name: "invalid arity", arity: 1, wantArity: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := []HeapOption{}
+			if tt.arity != 0 {
+				options = append(options, HeapArity(tt.arity))
+			}
+			items := []int{9, 4, 7, 1, 8, 2, 6, 3, 5, 0}
+			h := NewHeapFromSlice(items, func(a, b int) bool { return a < b }, options...)
+			if h.arity != tt.wantArity {
+				t.Fatalf("Heap arity = %v, want %v", h.arity, tt.wantArity)
+			}
+			if err := h.Validate(); err != nil {
+				t.Fatalf("Heap.Validate() error = %v", err)
+			}
+			// the children of the root are the items 1 to arity.
+			for index := 1; index <= tt.wantArity; index++ {
+				h.items[index] = -index
+			}
+			if err := h.Validate(); err == nil {
+				t.Errorf("Heap.Validate() error = nil with children less than the root")
+			}
+		})
+	}
+}
+
+// BenchmarkHeap_arity compares arities for an insert-heavy workload, that
+// inserts many items and polls a few, and a poll-heavy one, that polls
+// every item.
+func BenchmarkHeap_arity(b *testing.B) {
+	random := rand.New(rand.NewSource(1))
+	items := make([]int, 1<<18)
+	for i := range items {
+		items[i] = random.Int()
+	}
+	workloads := []struct {
+		name  string
+		polls int
+	}{
+		{name: "insert heavy", polls: len(items) / 16},
+		{name: "poll heavy", polls: len(items)},
+	}
+	for _, workload := range workloads {
+		for _, arity := range []int{2, 4, 8, 16} {
+			b.Run(workload.name+" arity "+strconv.Itoa(arity), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					h := NewMinHeapOf[int](HeapArity(arity))
+					for _, item := range items {
+						h.Insert(item)
+					}
+					for j := 0; j < workload.polls; j++ {
+						h.Poll()
+					}
+				}
+			})
+		}
+	}
+}
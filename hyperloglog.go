@@ -0,0 +1,183 @@
+package datastructures
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+var (
+	// ErrIncompatibleSketch is returned when sketches with different sizes,
+	// seeds or update modes are merged.
+	ErrIncompatibleSketch = errors.New("incompatible sketch")
+	// ErrInvalidSketchEncoding is returned when a sketch cannot be decoded.
+	ErrInvalidSketchEncoding = errors.New("invalid sketch encoding")
+)
+
+const (
+	hyperLogLogMinPrecision     = 4
+	hyperLogLogMaxPrecision     = 18
+	defaultHyperLogLogPrecision = 14
+
+	sketchEncodingVersion = 1
+	// hyperLogLogHeaderSize is the size of the encoding header: a kind
+	// byte, a version byte, the precision and the seed.
+	hyperLogLogHeaderSize      = 2 + 1 + 8
+	hyperLogLogKind       byte = 'H'
+)
+
+// sketchOptions is a helper function that makes the seed of a sketch 0
+// unless a seed option is provided, so that sketches created in different
+// processes can be merged.
+func sketchOptions(options []HashTableOption) []HashTableOption {
+	return append([]HashTableOption{HashTableSeed(0)}, options...)
+}
+
+// HyperLogLog represents a HyperLogLog data structure, a sketch that
+// estimates the number of distinct keys added to it with a fixed amount
+// of memory.
+//
+// the standard error of the estimate is about 1.04/sqrt(2^precision).
+type HyperLogLog struct {
+	precision uint8
+	// registers holds the largest rank seen for every register.
+	registers []uint8
+	hasher    Hasher
+	seed      uint64
+}
+
+// NewHyperLogLog returns a new HyperLogLog with 2^precision registers.
+//
+// precision must be between 4 and 18, else 14 is used. the seed is 0 unless
+// the HashTableSeed option is used, sketches must have the same precision
+// and seed to be merged.
+func NewHyperLogLog(precision uint8, options ...HashTableOption) *HyperLogLog {
+	if precision < hyperLogLogMinPrecision || precision > hyperLogLogMaxPrecision {
+		precision = defaultHyperLogLogPrecision
+	}
+	config := newHashTableConfig(0, 0, sketchOptions(options))
+	return &HyperLogLog{
+		precision: precision,
+		registers: make([]uint8, 1<<precision),
+		hasher:    config.hasher,
+		seed:      config.seed,
+	}
+}
+
+// Add adds key to the HyperLogLog.
+func (h *HyperLogLog) Add(key interface{}) error {
+	hash, err := h.hashKey(key)
+	if err != nil {
+		return err
+	}
+	// the first precision bits pick the register, the rank is the
+	// position of the first set bit in the remaining bits.
+	index := hash >> (64 - h.precision)
+	rank := uint8(bits.LeadingZeros64(hash<<h.precision|1<<(h.precision-1)) + 1)
+	if rank > h.registers[index] {
+		h.registers[index] = rank
+	}
+	return nil
+}
+
+// Count returns the estimated number of distinct keys added to the
+// HyperLogLog.
+func (h *HyperLogLog) Count() uint64 {
+	m := float64(len(h.registers))
+	sum := 0.0
+	zeros := 0
+	for _, rank := range h.registers {
+		sum += 1 / float64(uint64(1)<<rank)
+		if rank == 0 {
+			zeros++
+		}
+	}
+	estimate := hyperLogLogAlpha(len(h.registers)) * m * m / sum
+	// small cardinalities are estimated better by counting the empty
+	// registers (linear counting).
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(estimate + 0.5)
+}
+
+// Merge adds the keys of other to the HyperLogLog.
+//
+// both sketches must have the same precision and seed.
+func (h *HyperLogLog) Merge(other *HyperLogLog) error {
+	if h.precision != other.precision || h.seed != other.seed {
+		return fmt.Errorf(
+			"%w: (precision %v, seed %v) and (precision %v, seed %v)",
+			ErrIncompatibleSketch, h.precision, h.seed, other.precision, other.seed,
+		)
+	}
+	for i, rank := range other.registers {
+		if rank > h.registers[i] {
+			h.registers[i] = rank
+		}
+	}
+	return nil
+}
+
+// Precision returns the precision of the HyperLogLog.
+func (h *HyperLogLog) Precision() int {
+	return int(h.precision)
+}
+
+// MarshalBinary encodes the HyperLogLog, the seed is part of the encoding.
+func (h *HyperLogLog) MarshalBinary() ([]byte, error) {
+	data := make([]byte, hyperLogLogHeaderSize, hyperLogLogHeaderSize+len(h.registers))
+	data[0] = hyperLogLogKind
+	data[1] = sketchEncodingVersion
+	data[2] = h.precision
+	binary.BigEndian.PutUint64(data[3:], h.seed)
+	return append(data, h.registers...), nil
+}
+
+// UnmarshalBinary decodes a HyperLogLog encoded with MarshalBinary.
+//
+// the hasher of the HyperLogLog is kept, DefaultHasher is used if it has
+// none.
+func (h *HyperLogLog) UnmarshalBinary(data []byte) error {
+	if len(data) < hyperLogLogHeaderSize || data[0] != hyperLogLogKind || data[1] != sketchEncodingVersion {
+		return fmt.Errorf("%w: bad header", ErrInvalidSketchEncoding)
+	}
+	precision := data[2]
+	if precision < hyperLogLogMinPrecision || precision > hyperLogLogMaxPrecision {
+		return fmt.Errorf("%w: precision %v", ErrInvalidSketchEncoding, precision)
+	}
+	registers := data[hyperLogLogHeaderSize:]
+	if len(registers) != 1<<precision {
+		return fmt.Errorf("%w: %v registers, want %v", ErrInvalidSketchEncoding, len(registers), 1<<precision)
+	}
+	h.precision = precision
+	h.seed = binary.BigEndian.Uint64(data[3:])
+	h.registers = append([]uint8(nil), registers...)
+	return nil
+}
+
+// hashKey is a helper method that returns the hash of key.
+func (h *HyperLogLog) hashKey(key interface{}) (uint64, error) {
+	hasher := h.hasher
+	if hasher == nil {
+		hasher = DefaultHasher
+	}
+	return hasher.Hash(key, h.seed)
+}
+
+// hyperLogLogAlpha is a helper function that returns the bias correction
+// constant for m registers.
+func hyperLogLogAlpha(m int) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	default:
+		return 0.7213 / (1 + 1.079/float64(m))
+	}
+}
@@ -0,0 +1,119 @@
+package datastructures
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestHyperLogLog_Count(t *testing.T) {
+	tests := []struct {
+		name      string
+		precision uint8
+		distinct  int
+		repeats   int
+		maxError  float64
+	}{
+		{name: "empty", precision: 14, distinct: 0, repeats: 1},
+		{name: "small cardinality", precision: 14, distinct: 100, repeats: 3, maxError: 0.02},
+		{name: "large cardinality", precision: 14, distinct: 100000, repeats: 2, maxError: 0.03},
+		{name: "low precision", precision: 6, distinct: 10000, repeats: 1, maxError: 0.4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHyperLogLog(tt.precision)
+			for r := 0; r < tt.repeats; r++ {
+				for i := 0; i < tt.distinct; i++ {
+					if err := h.Add("user " + strconv.Itoa(i)); err != nil {
+						t.Fatalf("HyperLogLog.Add() error = %v", err)
+					}
+				}
+			}
+			got := float64(h.Count())
+			if math.Abs(got-float64(tt.distinct)) > tt.maxError*float64(tt.distinct) {
+				t.Errorf("HyperLogLog.Count() = %v, want %v ± %v%%", got, tt.distinct, tt.maxError*100)
+			}
+		})
+	}
+}
+
+func TestNewHyperLogLog(t *testing.T) {
+	tests := []struct {
+		name          string
+		precision     uint8
+		wantPrecision int
+	}{
+		{name: "valid precision", precision: 10, wantPrecision: 10},
+		{name: "precision too low", precision: 2, wantPrecision: 14},
+		{name: "precision too high", precision: 30, wantPrecision: 14},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHyperLogLog(tt.precision)
+			if h.Precision() != tt.wantPrecision || len(h.registers) != 1<<tt.wantPrecision {
+				t.Errorf("NewHyperLogLog() precision = %v, want %v", h.Precision(), tt.wantPrecision)
+			}
+		})
+	}
+}
+
+func TestHyperLogLog_Merge(t *testing.T) {
+	first, second := NewHyperLogLog(12), NewHyperLogLog(12)
+	for i := 0; i < 6000; i++ {
+		first.Add(i)
+	}
+	for i := 4000; i < 10000; i++ {
+		second.Add(i)
+	}
+	if err := first.Merge(second); err != nil {
+		t.Fatalf("HyperLogLog.Merge() error = %v", err)
+	}
+	if got := float64(first.Count()); math.Abs(got-10000) > 500 {
+		t.Errorf("HyperLogLog.Count() = %v after merge, want about %v", got, 10000)
+	}
+
+	incompatible := []*HyperLogLog{NewHyperLogLog(10), NewHyperLogLog(12, HashTableSeed(1))}
+	for _, other := range incompatible {
+		if err := first.Merge(other); !errors.Is(err, ErrIncompatibleSketch) {
+			t.Errorf("HyperLogLog.Merge() error = %v, wantErr %v", err, ErrIncompatibleSketch)
+		}
+	}
+}
+
+func TestHyperLogLog_MarshalBinary(t *testing.T) {
+	h := NewHyperLogLog(8, HashTableSeed(5))
+	for i := 0; i < 1000; i++ {
+		h.Add(i)
+	}
+	data, _ := h.MarshalBinary()
+	decoded := &HyperLogLog{}
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("HyperLogLog.UnmarshalBinary() error = %v", err)
+	}
+	if decoded.Count() != h.Count() {
+		t.Errorf("decoded HyperLogLog.Count() = %v, want %v", decoded.Count(), h.Count())
+	}
+	// a sketch decoded in another process keeps adding keys the same way.
+	before := decoded.Count()
+	decoded.Add(1)
+	if decoded.Count() != before {
+		t.Errorf("decoded HyperLogLog.Count() changed after adding a known key")
+	}
+	if err := decoded.Merge(h); err != nil {
+		t.Errorf("HyperLogLog.Merge() error = %v", err)
+	}
+
+	invalid := map[string][]byte{
+		"empty":             {},
+		"truncated":         data[:len(data)-1],
+		"invalid precision": append([]byte{hyperLogLogKind, sketchEncodingVersion, 40}, data[3:]...),
+	}
+	for name, data := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if err := (&HyperLogLog{}).UnmarshalBinary(data); !errors.Is(err, ErrInvalidSketchEncoding) {
+				t.Errorf("HyperLogLog.UnmarshalBinary() error = %v, wantErr %v", err, ErrInvalidSketchEncoding)
+			}
+		})
+	}
+}
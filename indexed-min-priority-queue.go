@@ -0,0 +1,172 @@
+package datastructures
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrKeyExists is returned when a key that is already stored is added again.
+var ErrKeyExists = errors.New("key already exists")
+
+// indexedMinPriorityQueueItem is an item of an indexed min-priority queue.
+type indexedMinPriorityQueueItem[K comparable] struct {
+	key      K
+	priority float64
+}
+
+// IndexedMinPriorityQueue represents a min-priority queue data structure
+// whose items are identified by a key, so that the priority of an item
+// can be changed while it is in the queue.
+//
+// the items are kept in a binary heap and a map stores the position of
+// every key in the heap, all operations are O(log n).
+type IndexedMinPriorityQueue[K comparable] struct {
+	items     []indexedMinPriorityQueueItem[K]
+	positions map[K]int
+}
+
+// NewIndexedMinPriorityQueue returns an indexed min-priority queue data
+// structure.
+func NewIndexedMinPriorityQueue[K comparable]() *IndexedMinPriorityQueue[K] {
+	return &IndexedMinPriorityQueue[K]{
+		positions: make(map[K]int),
+	}
+}
+
+// Push adds key to the priority queue with priority.
+//
+// it returns ErrKeyExists if key is already in the queue, use Update to
+// change its priority.
+func (q *IndexedMinPriorityQueue[K]) Push(key K, priority float64) error {
+	if _, ok := q.positions[key]; ok {
+		return fmt.Errorf("%w: %v", ErrKeyExists, key)
+	}
+	q.items = append(q.items, indexedMinPriorityQueueItem[K]{key: key, priority: priority})
+	q.positions[key] = len(q.items) - 1
+	q.siftUp(len(q.items) - 1)
+	return nil
+}
+
+// Pop removes the key with the smallest priority from the priority queue.
+func (q *IndexedMinPriorityQueue[K]) Pop() (K, float64, error) {
+	if len(q.items) == 0 {
+		var zero K
+		return zero, 0, errors.New("queue is empty")
+	}
+	item := q.items[0]
+	q.removeAt(0)
+	return item.key, item.priority, nil
+}
+
+// Peek returns the key with the smallest priority without removing it.
+func (q *IndexedMinPriorityQueue[K]) Peek() (K, float64, error) {
+	if len(q.items) == 0 {
+		var zero K
+		return zero, 0, errors.New("queue is empty")
+	}
+	return q.items[0].key, q.items[0].priority, nil
+}
+
+// Update changes the priority of key, the priority can be lowered
+// (decrease-key) or raised.
+func (q *IndexedMinPriorityQueue[K]) Update(key K, priority float64) error {
+	position, ok := q.positions[key]
+	if !ok {
+		return fmt.Errorf("%w: %v", ErrKeyNotFound, key)
+	}
+	previous := q.items[position].priority
+	q.items[position].priority = priority
+	if priority < previous {
+		q.siftUp(position)
+	} else {
+		q.siftDown(position)
+	}
+	return nil
+}
+
+// Remove removes key from the priority queue and returns its priority.
+func (q *IndexedMinPriorityQueue[K]) Remove(key K) (float64, error) {
+	position, ok := q.positions[key]
+	if !ok {
+		return 0, fmt.Errorf("%w: %v", ErrKeyNotFound, key)
+	}
+	priority := q.items[position].priority
+	q.removeAt(position)
+	return priority, nil
+}
+
+// PriorityOf returns the priority of key.
+func (q *IndexedMinPriorityQueue[K]) PriorityOf(key K) (float64, error) {
+	position, ok := q.positions[key]
+	if !ok {
+		return 0, fmt.Errorf("%w: %v", ErrKeyNotFound, key)
+	}
+	return q.items[position].priority, nil
+}
+
+// Contains returns true if key is in the queue; else false.
+func (q *IndexedMinPriorityQueue[K]) Contains(key K) bool {
+	_, ok := q.positions[key]
+	return ok
+}
+
+// Size returns the size of the priority queue.
+func (q *IndexedMinPriorityQueue[K]) Size() int {
+	return len(q.items)
+}
+
+// removeAt is a helper method that removes the item at position, the last
+// item takes its place and is moved up or down.
+func (q *IndexedMinPriorityQueue[K]) removeAt(position int) {
+	last := len(q.items) - 1
+	delete(q.positions, q.items[position].key)
+	if position != last {
+		q.items[position] = q.items[last]
+		q.positions[q.items[position].key] = position
+	}
+	q.items = q.items[:last]
+	if position < last {
+		q.siftDown(position)
+		q.siftUp(position)
+	}
+}
+
+// siftUp is a helper method that moves the item at position up until its
+// parent has a smaller or equal priority.
+func (q *IndexedMinPriorityQueue[K]) siftUp(position int) {
+	for position > 0 {
+		parent := (position - 1) / 2
+		if q.items[parent].priority <= q.items[position].priority {
+			return
+		}
+		q.swap(parent, position)
+		position = parent
+	}
+}
+
+// siftDown is a helper method that moves the item at position down until
+// its children have larger or equal priorities.
+func (q *IndexedMinPriorityQueue[K]) siftDown(position int) {
+	for {
+		smallest := position
+		left, right := 2*position+1, 2*position+2
+		if left < len(q.items) && q.items[left].priority < q.items[smallest].priority {
+			smallest = left
+		}
+		if right < len(q.items) && q.items[right].priority < q.items[smallest].priority {
+			smallest = right
+		}
+		if smallest == position {
+			return
+		}
+		q.swap(position, smallest)
+		position = smallest
+	}
+}
+
+// swap is a helper method that swaps two items and their positions.
+func (q *IndexedMinPriorityQueue[K]) swap(i, j int) {
+	q.items[i], q.items[j] = q.items[j], q.items[i]
+	q.positions[q.items[i].key] = i
+	q.positions[q.items[j].key] = j
+}
@@ -0,0 +1,192 @@
+package datastructures
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// checkIndexedMinPriorityQueue is a test helper that checks the heap
+// property and that every key is stored at its position.
+func checkIndexedMinPriorityQueue[K comparable](t *testing.T, q *IndexedMinPriorityQueue[K]) {
+	t.Helper()
+	if len(q.positions) != len(q.items) {
+		t.Fatalf("IndexedMinPriorityQueue has %v positions for %v items", len(q.positions), len(q.items))
+	}
+	for i, item := range q.items {
+		if q.positions[item.key] != i {
+			t.Fatalf("IndexedMinPriorityQueue position of %v = %v, want %v", item.key, q.positions[item.key], i)
+		}
+		if i > 0 && q.items[(i-1)/2].priority > item.priority {
+			t.Fatalf("IndexedMinPriorityQueue item %v is smaller than its parent", i)
+		}
+	}
+}
+
+func TestIndexedMinPriorityQueue_operations(t *testing.T) {
+	tests := []struct {
+		name       string
+		operations func(q *IndexedMinPriorityQueue[string])
+		want       []string
+	}{
+		{
+			name: "pushing items",
+			operations: func(q *IndexedMinPriorityQueue[string]) {
+				q.Push("c", 3)
+				q.Push("a", 1)
+				q.Push("d", 4)
+				q.Push("b", 2)
+			},
+			want: []string{"a", "b", "c", "d"},
+		},
+		{
+			name: "decreasing a priority",
+			operations: func(q *IndexedMinPriorityQueue[string]) {
+				q.Push("a", 1)
+				q.Push("b", 2)
+				q.Push("c", 3)
+				q.Update("c", 0)
+			},
+			want: []string{"c", "a", "b"},
+		},
+		{
+			name: "increasing a priority",
+			operations: func(q *IndexedMinPriorityQueue[string]) {
+				q.Push("a", 1)
+				q.Push("b", 2)
+				q.Push("c", 3)
+				q.Update("a", 10)
+			},
+			want: []string{"b", "c", "a"},
+		},
+		{
+			name: "removing items",
+			operations: func(q *IndexedMinPriorityQueue[string]) {
+				q.Push("a", 1)
+				q.Push("b", 2)
+				q.Push("c", 3)
+				q.Push("d", 4)
+				q.Remove("b")
+				q.Remove("d")
+			},
+			want: []string{"a", "c"},
+		},
+		{
+			name: "empty queue",
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := NewIndexedMinPriorityQueue[string]()
+			if tt.operations != nil {
+				tt.operations(q)
+			}
+			checkIndexedMinPriorityQueue(t, q)
+			got := []string{}
+			for q.Size() > 0 {
+				key, _, _ := q.Pop()
+				got = append(got, key)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("IndexedMinPriorityQueue.Pop() order = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIndexedMinPriorityQueue_errors(t *testing.T) {
+	q := NewIndexedMinPriorityQueue[int]()
+	if _, _, err := q.Pop(); err == nil {
+		t.Errorf("IndexedMinPriorityQueue.Pop() error = nil on an empty queue")
+	}
+	if _, _, err := q.Peek(); err == nil {
+		t.Errorf("IndexedMinPriorityQueue.Peek() error = nil on an empty queue")
+	}
+	q.Push(1, 5)
+	if err := q.Push(1, 2); !errors.Is(err, ErrKeyExists) {
+		t.Errorf("IndexedMinPriorityQueue.Push() error = %v, wantErr %v", err, ErrKeyExists)
+	}
+	if err := q.Update(2, 1); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("IndexedMinPriorityQueue.Update() error = %v, wantErr %v", err, ErrKeyNotFound)
+	}
+	if _, err := q.Remove(2); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("IndexedMinPriorityQueue.Remove() error = %v, wantErr %v", err, ErrKeyNotFound)
+	}
+	if _, err := q.PriorityOf(2); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("IndexedMinPriorityQueue.PriorityOf() error = %v, wantErr %v", err, ErrKeyNotFound)
+	}
+	if priority, err := q.PriorityOf(1); err != nil || priority != 5 {
+		t.Errorf("IndexedMinPriorityQueue.PriorityOf() = %v, %v, want %v", priority, err, 5)
+	}
+}
+
+func TestIndexedMinPriorityQueue_random(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	q := NewIndexedMinPriorityQueue[int]()
+	priorities := map[int]float64{}
+	for i := 0; i < 5000; i++ {
+		key := random.Intn(200)
+		switch random.Intn(4) {
+		case 0:
+			if q.Push(key, float64(random.Intn(50))) == nil {
+				priorities[key], _ = q.PriorityOf(key)
+			}
+		case 1:
+			if priority := float64(random.Intn(50)); q.Update(key, priority) == nil {
+				priorities[key] = priority
+			}
+		case 2:
+			if _, err := q.Remove(key); err == nil {
+				delete(priorities, key)
+			}
+		case 3:
+			key, priority, err := q.Pop()
+			if err != nil {
+				continue
+			}
+			for _, other := range priorities {
+				if other < priority {
+					t.Fatalf("IndexedMinPriorityQueue.Pop() = %v, %v, a smaller priority %v is queued", key, priority, other)
+				}
+			}
+			delete(priorities, key)
+		}
+		if q.Size() != len(priorities) {
+			t.Fatalf("IndexedMinPriorityQueue.Size() = %v, want %v", q.Size(), len(priorities))
+		}
+	}
+	checkIndexedMinPriorityQueue(t, q)
+}
+
+func TestIndexedMinPriorityQueue_dijkstra(t *testing.T) {
+	edges := map[string]map[string]float64{
+		"a": {"b": 7, "c": 9, "f": 14},
+		"b": {"a": 7, "c": 10, "d": 15},
+		"c": {"a": 9, "b": 10, "d": 11, "f": 2},
+		"d": {"b": 15, "c": 11, "e": 6},
+		"e": {"d": 6, "f": 9},
+		"f": {"a": 14, "c": 2, "e": 9},
+	}
+	distances := map[string]float64{}
+	q := NewIndexedMinPriorityQueue[string]()
+	for node := range edges {
+		q.Push(node, math.Inf(1))
+	}
+	q.Update("a", 0)
+	for q.Size() > 0 {
+		node, distance, _ := q.Pop()
+		distances[node] = distance
+		for next, weight := range edges[node] {
+			if current, err := q.PriorityOf(next); err == nil && distance+weight < current {
+				q.Update(next, distance+weight)
+			}
+		}
+	}
+	want := map[string]float64{"a": 0, "b": 7, "c": 9, "d": 20, "e": 20, "f": 11}
+	if !reflect.DeepEqual(distances, want) {
+		t.Errorf("dijkstra distances = %v, want %v", distances, want)
+	}
+}
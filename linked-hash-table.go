@@ -0,0 +1,205 @@
+package datastructures
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// LinkedHashTable represents a hash table data structure that remembers
+// the order of its entries.
+//
+// the entries are kept in a DoublyLinkedList in insertion order, or in
+// access order (least recently used first) for a table created with
+// NewAccessOrderLinkedHashTable, and a HashMap maps every key to its node.
+type LinkedHashTable struct {
+	nodes       *HashMap[interface{}, *DoublyLinkedListNode]
+	entries     *DoublyLinkedList
+	accessOrder bool
+}
+
+// NewLinkedHashTable returns a new linked hash table that iterates in
+// insertion order, updating the value of a key does not change its
+// position.
+func NewLinkedHashTable(size int, options ...HashTableOption) *LinkedHashTable {
+	return &LinkedHashTable{
+		nodes:   NewHashMap[interface{}, *DoublyLinkedListNode](size, options...),
+		entries: NewDoublyLinkedList(),
+	}
+}
+
+// NewAccessOrderLinkedHashTable returns a new linked hash table that
+// iterates in access order, Set and Get move the key to the end.
+func NewAccessOrderLinkedHashTable(size int, options ...HashTableOption) *LinkedHashTable {
+	h := NewLinkedHashTable(size, options...)
+	h.accessOrder = true
+	return h
+}
+
+// Set sets a new <Key, Value> item in the hash table.
+//
+// a new key is added at the end.
+func (h *LinkedHashTable) Set(key interface{}, value interface{}) error {
+	node, found := h.nodes.Get(key)
+	if found {
+		node.Data = HashTableEntry{Key: node.Data.(HashTableEntry).Key, Value: value}
+		if h.accessOrder {
+			h.moveToEnd(node)
+		}
+		return nil
+	}
+	node = &DoublyLinkedListNode{Data: HashTableEntry{Key: key, Value: value}}
+	if err := h.nodes.Set(key, node); err != nil {
+		return err
+	}
+	h.entries.Add(node)
+	return nil
+}
+
+// Get retrieves an item from the hash table using the key.
+func (h *LinkedHashTable) Get(key interface{}) (interface{}, error) {
+	node, err := h.node(key)
+	if err != nil {
+		return nil, err
+	}
+	if h.accessOrder {
+		h.moveToEnd(node)
+	}
+	return node.Data.(HashTableEntry).Value, nil
+}
+
+// Delete removes an item from the hash table in key position.
+//
+// if there is no item at key position, delete does nothing.
+func (h *LinkedHashTable) Delete(key interface{}) {
+	node, found := h.nodes.Get(key)
+	if !found {
+		return
+	}
+	h.nodes.Delete(key)
+	h.entries.Remove(node)
+}
+
+// MoveToEnd moves key to the end of the hash table.
+func (h *LinkedHashTable) MoveToEnd(key interface{}) error {
+	node, err := h.node(key)
+	if err != nil {
+		return err
+	}
+	h.moveToEnd(node)
+	return nil
+}
+
+// First returns the first entry of the hash table, the oldest key in
+// insertion order or the least recently used key in access order.
+//
+// it returns false if the hash table is empty.
+func (h *LinkedHashTable) First() (HashTableEntry, bool) {
+	if h.entries.IsEmpty() {
+		return HashTableEntry{}, false
+	}
+	return h.entries.GetHead().Data.(HashTableEntry), true
+}
+
+// Last returns the last entry of the hash table.
+//
+// it returns false if the hash table is empty.
+func (h *LinkedHashTable) Last() (HashTableEntry, bool) {
+	if h.entries.IsEmpty() {
+		return HashTableEntry{}, false
+	}
+	return h.entries.GetTail().Data.(HashTableEntry), true
+}
+
+// Iterate iterates through the hash table in order and executes the
+// callback function f for each iteration.
+func (h *LinkedHashTable) Iterate(f func(key, value interface{})) {
+	h.entries.Iterate(func(_ int, node *DoublyLinkedListNode) {
+		entry := node.Data.(HashTableEntry)
+		f(entry.Key, entry.Value)
+	})
+}
+
+// Size returns the number of buckets in the hash table.
+func (h *LinkedHashTable) Size() int {
+	return h.nodes.Size()
+}
+
+// Elements returns the number of elements in the hash table.
+func (h *LinkedHashTable) Elements() int {
+	return h.entries.Size()
+}
+
+// MarshalJSON encodes the hash table as a JSON object with the keys in
+// order.
+//
+// the keys must be strings, integers or implement encoding.TextMarshaler.
+func (h *LinkedHashTable) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteByte('{')
+	for node := h.entries.GetHead(); node != nil; node = node.Next {
+		entry := node.Data.(HashTableEntry)
+		key, err := linkedHashTableJSONKey(entry.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(entry.Value)
+		if err != nil {
+			return nil, err
+		}
+		if node != h.entries.GetHead() {
+			buffer.WriteByte(',')
+		}
+		buffer.Write(key)
+		buffer.WriteByte(':')
+		buffer.Write(value)
+	}
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
+
+// node is a helper method that returns the node of key.
+func (h *LinkedHashTable) node(key interface{}) (*DoublyLinkedListNode, error) {
+	node, _, err := h.nodes.lookup(key)
+	if err != nil {
+		return nil, err
+	}
+	if node == nil {
+		return nil, fmt.Errorf("%w: %v", ErrKeyNotFound, key)
+	}
+	return node.Data.(HashMapEntry[interface{}, *DoublyLinkedListNode]).Value, nil
+}
+
+// moveToEnd is a helper method that moves node to the end of the entries.
+func (h *LinkedHashTable) moveToEnd(node *DoublyLinkedListNode) {
+	if node == h.entries.GetTail() {
+		return
+	}
+	h.entries.Remove(node)
+	h.entries.Add(node)
+}
+
+// linkedHashTableJSONKey is a helper function that returns key encoded as
+// a JSON string.
+func linkedHashTableJSONKey(key interface{}) ([]byte, error) {
+	switch key := key.(type) {
+	case string:
+		return json.Marshal(key)
+	case encoding.TextMarshaler:
+		text, err := key.MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(string(text))
+	}
+	switch value := reflect.ValueOf(key); value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return json.Marshal(strconv.FormatInt(value.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return json.Marshal(strconv.FormatUint(value.Uint(), 10))
+	}
+	return nil, fmt.Errorf("%w: type of: (%v) cannot be used as a JSON key", ErrUnsupportedKey, reflect.TypeOf(key))
+}
@@ -0,0 +1,203 @@
+package datastructures
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func linkedHashTableKeys(h *LinkedHashTable) []interface{} {
+	keys := []interface{}{}
+	h.Iterate(func(key, value interface{}) {
+		keys = append(keys, key)
+	})
+	return keys
+}
+
+func TestLinkedHashTable_order(t *testing.T) {
+	tests := []struct {
+		name        string
+		accessOrder bool
+		operations  func(h *LinkedHashTable)
+		want        []interface{}
+	}{
+		{
+			name: "insertion order",
+			operations: func(h *LinkedHashTable) {
+				h.Set("c", 1)
+				h.Set("a", 2)
+				h.Set("b", 3)
+			},
+			want: []interface{}{"c", "a", "b"},
+		},
+		{
+			name: "updating a key keeps its position",
+			operations: func(h *LinkedHashTable) {
+				h.Set("c", 1)
+				h.Set("a", 2)
+				h.Set("c", 3)
+				h.Get("c")
+			},
+			want: []interface{}{"c", "a"},
+		},
+		{
+			name: "deleting and adding a key moves it to the end",
+			operations: func(h *LinkedHashTable) {
+				h.Set("c", 1)
+				h.Set("a", 2)
+				h.Delete("c")
+				h.Set("c", 3)
+			},
+			want: []interface{}{"a", "c"},
+		},
+		{
+			name:        "access order",
+			accessOrder: true,
+			operations: func(h *LinkedHashTable) {
+				h.Set("c", 1)
+				h.Set("a", 2)
+				h.Set("b", 3)
+				h.Get("c")
+				h.Set("a", 4)
+			},
+			want: []interface{}{"b", "c", "a"},
+		},
+		{
+			name: "moving a key to the end",
+			operations: func(h *LinkedHashTable) {
+				h.Set(1, 1)
+				h.Set(2, 2)
+				h.Set(3, 3)
+				h.MoveToEnd(1)
+				h.MoveToEnd(3)
+			},
+			want: []interface{}{2, 1, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewLinkedHashTable(2)
+			if tt.accessOrder {
+				h = NewAccessOrderLinkedHashTable(2)
+			}
+			tt.operations(h)
+			if got := linkedHashTableKeys(h); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LinkedHashTable.Iterate() keys = %v, want %v", got, tt.want)
+			}
+			if h.Elements() != len(tt.want) {
+				t.Errorf("LinkedHashTable.Elements() = %v, want %v", h.Elements(), len(tt.want))
+			}
+		})
+	}
+}
+
+func TestLinkedHashTable_orderAfterResize(t *testing.T) {
+	h := NewLinkedHashTable(1)
+	want := []interface{}{}
+	for i := 100; i > 0; i-- {
+		h.Set(i, i)
+		want = append(want, i)
+	}
+	if got := linkedHashTableKeys(h); !reflect.DeepEqual(got, want) {
+		t.Errorf("LinkedHashTable.Iterate() keys = %v, want %v", got, want)
+	}
+}
+
+func TestLinkedHashTable_FirstLast(t *testing.T) {
+	h := NewLinkedHashTable(4)
+	if _, ok := h.First(); ok {
+		t.Errorf("LinkedHashTable.First() ok = true on an empty table")
+	}
+	if _, ok := h.Last(); ok {
+		t.Errorf("LinkedHashTable.Last() ok = true on an empty table")
+	}
+	h.Set("a", 1)
+	h.Set("b", 2)
+	h.Set("c", 3)
+	if got, _ := h.First(); got != (HashTableEntry{Key: "a", Value: 1}) {
+		t.Errorf("LinkedHashTable.First() = %v, want %v", got, HashTableEntry{Key: "a", Value: 1})
+	}
+	if got, _ := h.Last(); got != (HashTableEntry{Key: "c", Value: 3}) {
+		t.Errorf("LinkedHashTable.Last() = %v, want %v", got, HashTableEntry{Key: "c", Value: 3})
+	}
+	h.Delete("a")
+	h.Delete("c")
+	first, _ := h.First()
+	last, _ := h.Last()
+	if first.Key != "b" || last.Key != "b" {
+		t.Errorf("LinkedHashTable.First(), Last() = %v, %v, want %v", first, last, "b")
+	}
+}
+
+func TestLinkedHashTable_errors(t *testing.T) {
+	h := NewLinkedHashTable(4)
+	if err := h.MoveToEnd("missing"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("LinkedHashTable.MoveToEnd() error = %v, wantErr %v", err, ErrKeyNotFound)
+	}
+	if err := h.Set([]int{1}, 1); !errors.Is(err, ErrUnsupportedKey) {
+		t.Errorf("LinkedHashTable.Set() error = %v, wantErr %v", err, ErrUnsupportedKey)
+	}
+	if h.Elements() != 0 {
+		t.Errorf("LinkedHashTable.Elements() = %v, want %v", h.Elements(), 0)
+	}
+}
+
+func TestLinkedHashTable_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		items   []HashTableEntry
+		want    string
+		wantErr error
+	}{
+		{
+			name: "string keys",
+			items: []HashTableEntry{
+				{Key: "zebra", Value: 1},
+				{Key: "apple", Value: []string{"x"}},
+				{Key: "mango", Value: nil},
+			},
+			want: `{"zebra":1,"apple":["x"],"mango":null}`,
+		},
+		{
+			name: "integer keys",
+			items: []HashTableEntry{
+				{Key: 10, Value: "ten"},
+				{Key: uint8(2), Value: "two"},
+			},
+			want: `{"10":"ten","2":"two"}`,
+		},
+		{
+			name: "escaped keys",
+			items: []HashTableEntry{
+				{Key: `"quoted"`, Value: true},
+			},
+			want: `{"\"quoted\"":true}`,
+		},
+		{
+			name: "empty table",
+			want: `{}`,
+		},
+		{
+			name: "float key",
+			items: []HashTableEntry{
+				{Key: 1.5, Value: 1},
+			},
+			wantErr: ErrUnsupportedKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewLinkedHashTable(2)
+			for _, item := range tt.items {
+				h.Set(item.Key, item.Value)
+			}
+			got, err := h.MarshalJSON()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("LinkedHashTable.MarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("LinkedHashTable.MarshalJSON() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
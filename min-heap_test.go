@@ -1,6 +1,7 @@
 package datastructures
 
 import (
+	"math"
 	"reflect"
 	"testing"
 )
@@ -10,13 +11,13 @@ func TestMinHeap_Insert(t *testing.T) {
 		name              string
 		items             []float64
 		want              []float64
-		expectedHashTable map[float64][]int
+		expectedPositions map[float64][]int
 	}{
 		{
 			name:  "inserting 3 items",
 			items: []float64{3.2, 4, 2},
 			want:  []float64{2, 4, 3.2},
-			expectedHashTable: map[float64][]int{
+			expectedPositions: map[float64][]int{
 				2:   {0},
 				3.2: {2},
 				4:   {1},
@@ -26,7 +27,7 @@ func TestMinHeap_Insert(t *testing.T) {
 			name:  "inserting 4 items",
 			items: []float64{3, 4, 2, 1},
 			want:  []float64{1, 2, 3, 4},
-			expectedHashTable: map[float64][]int{
+			expectedPositions: map[float64][]int{
 				1: {0},
 				2: {1},
 				3: {2},
@@ -35,13 +36,13 @@ func TestMinHeap_Insert(t *testing.T) {
 		},
 		{
 			name:              "inserting no item",
-			expectedHashTable: make(map[float64][]int),
+			expectedPositions: make(map[float64][]int),
 		},
 		{
 			name:  "inserting 1 item",
 			items: []float64{6},
 			want:  []float64{6},
-			expectedHashTable: map[float64][]int{
+			expectedPositions: map[float64][]int{
 				6: {0},
 			},
 		},
@@ -49,9 +50,9 @@ func TestMinHeap_Insert(t *testing.T) {
 			name:  "inserting 10 items",
 			items: []float64{9, 4, 6, 2, 6, 3, 7, 8, 3, 10},
 			want:  []float64{2, 3, 3, 4, 6, 6, 7, 9, 8, 10},
-			expectedHashTable: map[float64][]int{
+			expectedPositions: map[float64][]int{
 				2:  {0},
-				3:  {2, 1},
+				3:  {1, 2},
 				4:  {3},
 				6:  {4, 5},
 				7:  {6},
@@ -64,7 +65,7 @@ func TestMinHeap_Insert(t *testing.T) {
 			name:  "inserting 15 items",
 			items: []float64{9, 4, 6, 2, 6, 3, 7, 8, 3, 10, 5, 11, 1, 8, 100},
 			want:  []float64{1, 3, 2, 4, 5, 3, 7, 9, 8, 10, 6, 11, 6, 8, 100},
-			expectedHashTable: map[float64][]int{
+			expectedPositions: map[float64][]int{
 				1:   {0},
 				2:   {2},
 				3:   {1, 5},
@@ -92,8 +93,8 @@ func TestMinHeap_Insert(t *testing.T) {
 			if !reflect.DeepEqual(h.items, tt.want) {
 				t.Errorf("Heap.Insert() = %v, want %v", h.items, tt.want)
 			}
-			if !reflect.DeepEqual(h.hashTable, tt.expectedHashTable) {
-				t.Errorf("Heap.Insert() hashTable = %v, \n want %v", h.hashTable, tt.expectedHashTable)
+			if !reflect.DeepEqual(heapPositions(h), tt.expectedPositions) {
+				t.Errorf("Heap.Insert() positions = %v, \n want %v", heapPositions(h), tt.expectedPositions)
 			}
 		})
 	}
@@ -105,7 +106,7 @@ func TestMinHeap_Poll(t *testing.T) {
 		items             []float64
 		want              []float64
 		pollCount         int
-		expectedHashTable map[float64][]int
+		expectedPositions map[float64][]int
 		wantErr           bool
 	}{
 		{
@@ -113,10 +114,7 @@ func TestMinHeap_Poll(t *testing.T) {
 			items:     []float64{3, 5, 2, 6},
 			want:      []float64{6},
 			pollCount: 3,
-			expectedHashTable: map[float64][]int{
-				2: {},
-				3: {},
-				5: {},
+			expectedPositions: map[float64][]int{
 				6: {0},
 			},
 		},
@@ -125,58 +123,43 @@ func TestMinHeap_Poll(t *testing.T) {
 			items:             []float64{4},
 			pollCount:         2,
 			wantErr:           true,
-			expectedHashTable: map[float64][]int{4: {}},
+			expectedPositions: map[float64][]int{},
 		},
 		{
 			name:      "inserting 10 items - polling 9",
 			items:     []float64{9, 4, 6, 2, 6, 3, 7, 8, 3, 10},
 			want:      []float64{10},
 			pollCount: 9,
-			expectedHashTable: map[float64][]int{
-				2:  {},
-				3:  {},
-				4:  {},
-				6:  {},
-				7:  {},
-				9:  {},
-				8:  {},
+			expectedPositions: map[float64][]int{
 				10: {0},
 			},
 		},
 		{
 			name:      "inserting 10 items - polling 5",
 			items:     []float64{9, 4, 6, 2, 6, 3, 7, 8, 3, 10},
-			want:      []float64{6, 8, 7, 10, 9},
+			want:      []float64{6, 7, 10, 8, 9},
 			pollCount: 5,
-			expectedHashTable: map[float64][]int{
-				2:  {},
-				3:  {},
-				4:  {},
+			expectedPositions: map[float64][]int{
 				6:  {0},
-				7:  {2},
+				7:  {1},
 				9:  {4},
-				8:  {1},
-				10: {3},
+				8:  {3},
+				10: {2},
 			},
 		},
 		{
 			name:      "inserting 15 items - polling 7",
 			items:     []float64{9, 4, 6, 2, 6, 3, 7, 8, 3, 10, 5, 11, 1, 8, 100},
-			want:      []float64{6, 8, 7, 9, 10, 8, 11, 100},
+			want:      []float64{6, 8, 7, 8, 10, 11, 100, 9},
 			pollCount: 7,
-			expectedHashTable: map[float64][]int{
-				1:   {},
-				2:   {},
-				3:   {},
-				4:   {},
-				5:   {},
+			expectedPositions: map[float64][]int{
 				6:   {0},
 				7:   {2},
-				8:   {5, 1},
-				9:   {3},
+				8:   {1, 3},
+				9:   {7},
 				10:  {4},
-				11:  {6},
-				100: {7},
+				11:  {5},
+				100: {6},
 			},
 		},
 	}
@@ -198,8 +181,8 @@ func TestMinHeap_Poll(t *testing.T) {
 			if !reflect.DeepEqual(h.items, tt.want) {
 				t.Errorf("Heap.Poll() items = %v, \n want %v", h.items, tt.want)
 			}
-			if !reflect.DeepEqual(h.hashTable, tt.expectedHashTable) {
-				t.Errorf("Heap.Poll() hashTable = %v, \n want %v", h.hashTable, tt.expectedHashTable)
+			if !reflect.DeepEqual(heapPositions(h), tt.expectedPositions) {
+				t.Errorf("Heap.Poll() positions = %v, \n want %v", heapPositions(h), tt.expectedPositions)
 			}
 		})
 	}
@@ -214,7 +197,7 @@ func TestMinHeap_Remove(t *testing.T) {
 		name              string
 		items             []float64
 		want              []float64
-		expectedHashTable map[float64][]int
+		expectedPositions map[float64][]int
 		removedItem       float64
 		wantErr           bool
 	}{
@@ -224,8 +207,7 @@ func TestMinHeap_Remove(t *testing.T) {
 			want:        []float64{45.6, 56.2},
 			args:        args{item: 20.3},
 			removedItem: 20.3,
-			expectedHashTable: map[float64][]int{
-				20.3: {},
+			expectedPositions: map[float64][]int{
 				45.6: {0},
 				56.2: {1},
 			},
@@ -236,7 +218,7 @@ func TestMinHeap_Remove(t *testing.T) {
 			want:        []float64{2, 4, 5, 6, 6, 7},
 			args:        args{item: 3002},
 			removedItem: 0,
-			expectedHashTable: map[float64][]int{
+			expectedPositions: map[float64][]int{
 				2: {0},
 				4: {1},
 				5: {2},
@@ -251,31 +233,44 @@ func TestMinHeap_Remove(t *testing.T) {
 			want:        []float64{0.12, 0.23, 0.33, 8},
 			args:        args{item: 0.44},
 			removedItem: 0.44,
-			expectedHashTable: map[float64][]int{
-				0.44: {},
+			expectedPositions: map[float64][]int{
 				0.12: {0},
 				0.23: {1},
 				8:    {3},
 				0.33: {2},
 			},
 		},
+		{
+			name:        "removing an item smaller than the parent of its replacement",
+			items:       []float64{1, 10, 2, 11, 12, 3, 4},
+			want:        []float64{1, 4, 2, 10, 12, 3},
+			args:        args{item: 11},
+			removedItem: 11,
+			expectedPositions: map[float64][]int{
+				1:  {0},
+				2:  {2},
+				3:  {5},
+				4:  {1},
+				10: {3},
+				12: {4},
+			},
+		},
 		{
 			name:        "removing an extreme item",
 			items:       []float64{1, 5, 9},
 			want:        []float64{1, 5},
 			args:        args{item: 9},
 			removedItem: 9,
-			expectedHashTable: map[float64][]int{
+			expectedPositions: map[float64][]int{
 				1: {0},
 				5: {1},
-				9: {},
 			},
 		},
 		{
 			name:              "removing from an empty heap",
 			args:              args{item: 10},
 			wantErr:           true,
-			expectedHashTable: map[float64][]int{},
+			expectedPositions: map[float64][]int{},
 		},
 	}
 	for _, tt := range tests {
@@ -297,8 +292,8 @@ func TestMinHeap_Remove(t *testing.T) {
 			if !reflect.DeepEqual(h.items, tt.want) {
 				t.Errorf("Heap.Remove() items = %v, \n want %v", h.items, tt.want)
 			}
-			if !reflect.DeepEqual(h.hashTable, tt.expectedHashTable) {
-				t.Errorf("Heap.Remove() hashTable = %v, \n want %v", h.hashTable, tt.expectedHashTable)
+			if !reflect.DeepEqual(heapPositions(h), tt.expectedPositions) {
+				t.Errorf("Heap.Remove() positions = %v, \n want %v", heapPositions(h), tt.expectedPositions)
 			}
 		})
 	}
@@ -445,3 +440,122 @@ func TestMinHeap_GetList(t *testing.T) {
 		})
 	}
 }
+
+func TestNewMaxHeap(t *testing.T) {
+	h := NewMaxHeap()
+	for _, item := range []float64{3, 9.5, -1, 4} {
+		h.Insert(item)
+	}
+	if got, _ := h.Peek(); got != 9.5 {
+		t.Errorf("Heap.Peek() = %v, want %v", got, 9.5)
+	}
+	want := []float64{9.5, 4, 3, -1}
+	if got := heapPollAll(h); !reflect.DeepEqual(got, want) {
+		t.Errorf("Heap.Poll() order = %v, want %v", got, want)
+	}
+}
+
+func TestNewMinHeapFromSlice(t *testing.T) {
+	h := NewMinHeapFromSlice([]float64{4.5, -2, 8, 0.5, 4.5})
+	if err := h.Validate(); err != nil {
+		t.Fatalf("MinHeap.Validate() error = %v", err)
+	}
+	want := []float64{-2, 0.5, 4.5, 4.5, 8}
+	if got := heapPollAll(h); !reflect.DeepEqual(got, want) {
+		t.Errorf("MinHeap.Poll() order = %v, want %v", got, want)
+	}
+}
+
+func TestMinHeap_NaN(t *testing.T) {
+	tests := []struct {
+		name     string
+		newHeap  func() *MinHeap
+		remove   []float64
+		wantNaNs int
+		want     []float64
+	}{
+		{
+			name: "insert and poll",
+			newHeap: func() *MinHeap {
+				return NewMinHeap().Insert(1).Insert(math.NaN()).Insert(2)
+			},
+			wantNaNs: 1,
+			want:     []float64{1, 2},
+		},
+		{
+			name: "remove items next to NaN",
+			newHeap: func() *MinHeap {
+				return NewMinHeap().Insert(math.NaN()).Insert(3).Insert(math.NaN()).Insert(1).Insert(2)
+			},
+			remove:   []float64{3, 1},
+			wantNaNs: 2,
+			want:     []float64{2},
+		},
+		{
+			name: "heap from slice",
+			newHeap: func() *MinHeap {
+				return NewMinHeapFromSlice([]float64{4, math.NaN(), 5, math.NaN()})
+			},
+			remove:   []float64{5},
+			wantNaNs: 2,
+			want:     []float64{4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := tt.newHeap()
+			if h.Contains(math.NaN()) {
+				t.Errorf("MinHeap.Contains(NaN) = true, want false")
+			}
+			if _, err := h.Remove(math.NaN()); err == nil {
+				t.Errorf("MinHeap.Remove(NaN) error = nil, NaN is never found")
+			}
+			for _, item := range tt.remove {
+				if _, err := h.Remove(item); err != nil {
+					t.Fatalf("MinHeap.Remove() error = %v", err)
+				}
+				if err := h.Validate(); err != nil {
+					t.Fatalf("MinHeap.Validate() error = %v", err)
+				}
+			}
+			// cmp.Less orders NaN before every other item.
+			for i := 0; i < tt.wantNaNs; i++ {
+				if item, err := h.Poll(); err != nil || !math.IsNaN(item) {
+					t.Fatalf("MinHeap.Poll() = %v, %v, want NaN", item, err)
+				}
+				if err := h.Validate(); err != nil {
+					t.Fatalf("MinHeap.Validate() error = %v", err)
+				}
+			}
+			if got := heapPollAll(h); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MinHeap.Poll() order = %v, want %v", got, tt.want)
+			}
+			if len(h.positions) != 0 {
+				t.Errorf("MinHeap positions = %v, want none", h.positions)
+			}
+		})
+	}
+}
+
+func TestMinHeap_NaN_handles(t *testing.T) {
+	h := NewMinHeap()
+	three := h.Push(3)
+	nan := h.Push(math.NaN())
+	h.Push(1)
+	if err := h.Delete(nan); err != nil {
+		t.Fatalf("MinHeap.Delete() error = %v", err)
+	}
+	if err := h.DecreaseKey(three, math.NaN()); err != nil {
+		t.Fatalf("MinHeap.DecreaseKey() error = %v", err)
+	}
+	if err := h.Validate(); err != nil {
+		t.Fatalf("MinHeap.Validate() error = %v", err)
+	}
+	if got, _ := h.Poll(); !math.IsNaN(got) {
+		t.Errorf("MinHeap.Poll() = %v, want NaN", got)
+	}
+	want := []float64{1}
+	if got := heapPollAll(h); !reflect.DeepEqual(got, want) {
+		t.Errorf("MinHeap.Poll() order = %v, want %v", got, want)
+	}
+}
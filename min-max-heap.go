@@ -0,0 +1,190 @@
+package datastructures
+
+import (
+	"cmp"
+	"errors"
+	"math/bits"
+)
+
+// MinMaxHeap represents a min-max heap data structure, a double-ended
+// priority queue that finds both the smallest and the largest item.
+//
+// the items are stored in one array like a binary heap, the elements on
+// even levels are less than their descendants and the elements on odd
+// levels are greater than their descendants. Insert, PollMin and PollMax
+// are O(log n).
+type MinMaxHeap[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+// NewMinMaxHeap returns a new min-max heap data structure ordered by less.
+func NewMinMaxHeap[T any](less func(a, b T) bool) *MinMaxHeap[T] {
+	return &MinMaxHeap[T]{less: less}
+}
+
+// NewMinMaxHeapOf returns a new min-max heap of any ordered type.
+func NewMinMaxHeapOf[T cmp.Ordered]() *MinMaxHeap[T] {
+	return NewMinMaxHeap(cmp.Less[T])
+}
+
+// Insert adds a new item to the heap.
+func (h *MinMaxHeap[T]) Insert(item T) *MinMaxHeap[T] {
+	h.items = append(h.items, item)
+	h.pushUp(len(h.items) - 1)
+	return h
+}
+
+// PeekMin returns the smallest item of the heap without removing it.
+func (h *MinMaxHeap[T]) PeekMin() (T, error) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, errors.New("heap is empty")
+	}
+	return h.items[0], nil
+}
+
+// PeekMax returns the largest item of the heap without removing it.
+func (h *MinMaxHeap[T]) PeekMax() (T, error) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, errors.New("heap is empty")
+	}
+	return h.items[h.maxIndex()], nil
+}
+
+// PollMin removes the smallest item from the heap.
+func (h *MinMaxHeap[T]) PollMin() (T, error) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, errors.New("heap is empty")
+	}
+	return h.removeAt(0), nil
+}
+
+// PollMax removes the largest item from the heap.
+func (h *MinMaxHeap[T]) PollMax() (T, error) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, errors.New("heap is empty")
+	}
+	return h.removeAt(h.maxIndex()), nil
+}
+
+// Size returns the size of the heap.
+func (h *MinMaxHeap[T]) Size() int {
+	return len(h.items)
+}
+
+// maxIndex returns the index of the largest item, the root if it is the
+// only item or else the largest child of the root.
+func (h *MinMaxHeap[T]) maxIndex() int {
+	switch len(h.items) {
+	case 1:
+		return 0
+	case 2:
+		return 1
+	}
+	if h.less(h.items[1], h.items[2]) {
+		return 2
+	}
+	return 1
+}
+
+// removeAt removes the item at index, the last item takes its place and is
+// moved down.
+func (h *MinMaxHeap[T]) removeAt(index int) T {
+	item := h.items[index]
+	last := len(h.items) - 1
+	h.items[index] = h.items[last]
+	var zero T
+	h.items[last] = zero
+	h.items = h.items[:last]
+	if index < last {
+		h.pushDown(index)
+	}
+	return item
+}
+
+// isMinLevel returns true if index is on an even level.
+func isMinLevel(index int) bool {
+	return bits.Len(uint(index+1))%2 == 1
+}
+
+// pushUp moves the item at index up, first to the other kind of level if
+// it belongs there, then along its grandparents.
+func (h *MinMaxHeap[T]) pushUp(index int) {
+	if index == 0 {
+		return
+	}
+	parent := (index - 1) / 2
+	if isMinLevel(index) {
+		if h.less(h.items[parent], h.items[index]) {
+			h.items[index], h.items[parent] = h.items[parent], h.items[index]
+			h.pushUpLevel(parent, h.greater)
+			return
+		}
+		h.pushUpLevel(index, h.less)
+		return
+	}
+	if h.less(h.items[index], h.items[parent]) {
+		h.items[index], h.items[parent] = h.items[parent], h.items[index]
+		h.pushUpLevel(parent, h.less)
+		return
+	}
+	h.pushUpLevel(index, h.greater)
+}
+
+// pushUpLevel swaps the item at index with its grandparent while it comes
+// before it in the order of before.
+func (h *MinMaxHeap[T]) pushUpLevel(index int, before func(a, b T) bool) {
+	for index > 2 {
+		grandparent := (index - 3) / 4
+		if !before(h.items[index], h.items[grandparent]) {
+			return
+		}
+		h.items[index], h.items[grandparent] = h.items[grandparent], h.items[index]
+		index = grandparent
+	}
+}
+
+// pushDown moves the item at index down, on a min level the smallest
+// descendant moves up and on a max level the largest one does.
+func (h *MinMaxHeap[T]) pushDown(index int) {
+	before := h.less
+	if !isMinLevel(index) {
+		before = h.greater
+	}
+	for {
+		// best is the first of the children and grandchildren in the order
+		// of before.
+		best := -1
+		firstChild := 2*index + 1
+		for _, descendant := range [...]int{firstChild, firstChild + 1, 2*firstChild + 1, 2*firstChild + 2, 2*firstChild + 3, 2*firstChild + 4} {
+			if descendant < len(h.items) && (best == -1 || before(h.items[descendant], h.items[best])) {
+				best = descendant
+			}
+		}
+		if best == -1 || !before(h.items[best], h.items[index]) {
+			return
+		}
+		h.items[best], h.items[index] = h.items[index], h.items[best]
+		if best <= firstChild+1 {
+			// a child is on the other kind of level and has no descendants
+			// that must move.
+			return
+		}
+		// the item moved to a grandchild, it can be out of order with the
+		// parent of the grandchild, which is on the other kind of level.
+		parent := (best - 1) / 2
+		if before(h.items[parent], h.items[best]) {
+			h.items[best], h.items[parent] = h.items[parent], h.items[best]
+		}
+		index = best
+	}
+}
+
+// greater returns true if a comes after b.
+func (h *MinMaxHeap[T]) greater(a, b T) bool {
+	return h.less(b, a)
+}
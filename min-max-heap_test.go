@@ -0,0 +1,148 @@
+package datastructures
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// validateMinMaxHeap is a test helper that checks that every element on a
+// min level is not greater than its descendants and every element on a max
+// level is not less than them.
+func validateMinMaxHeap[T any](t *testing.T, h *MinMaxHeap[T]) {
+	t.Helper()
+	for index := 1; index < len(h.items); index++ {
+		for ancestor := (index - 1) / 2; ; ancestor = (ancestor - 1) / 2 {
+			if isMinLevel(ancestor) && h.less(h.items[index], h.items[ancestor]) {
+				t.Fatalf("MinMaxHeap item %v at %v is less than its ancestor %v", h.items[index], index, h.items[ancestor])
+			}
+			if !isMinLevel(ancestor) && h.less(h.items[ancestor], h.items[index]) {
+				t.Fatalf("MinMaxHeap item %v at %v is greater than its ancestor %v", h.items[index], index, h.items[ancestor])
+			}
+			if ancestor == 0 {
+				break
+			}
+		}
+	}
+}
+
+func TestMinMaxHeap_Poll(t *testing.T) {
+	tests := []struct {
+		name     string
+		items    []int
+		wantMins []int
+		wantMaxs []int
+	}{
+		{
+			name:     "one item",
+			items:    []int{4},
+			wantMins: []int{4},
+			wantMaxs: []int{4},
+		},
+		{
+			name:     "two items",
+			items:    []int{4, 9},
+			wantMins: []int{4, 9},
+			wantMaxs: []int{9, 4},
+		},
+		{
+			name:     "many items",
+			items:    []int{8, 71, 41, 31, 10, 11, 16, 46, 51, 31, 21, 13},
+			wantMins: []int{8, 10, 11, 13, 16, 21, 31, 31, 41, 46, 51, 71},
+			wantMaxs: []int{71, 51, 46, 41, 31, 31, 21, 16, 13, 11, 10, 8},
+		},
+		{
+			name:     "duplicates",
+			items:    []int{3, 3, 1, 3, 1},
+			wantMins: []int{1, 1, 3, 3, 3},
+			wantMaxs: []int{3, 3, 3, 1, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mins, maxs := NewMinMaxHeapOf[int](), NewMinMaxHeapOf[int]()
+			for _, item := range tt.items {
+				mins.Insert(item)
+				maxs.Insert(item)
+			}
+			validateMinMaxHeap(t, mins)
+			gotMins, gotMaxs := []int{}, []int{}
+			for mins.Size() > 0 {
+				item, _ := mins.PollMin()
+				gotMins = append(gotMins, item)
+				validateMinMaxHeap(t, mins)
+			}
+			for maxs.Size() > 0 {
+				item, _ := maxs.PollMax()
+				gotMaxs = append(gotMaxs, item)
+				validateMinMaxHeap(t, maxs)
+			}
+			if !reflect.DeepEqual(gotMins, tt.wantMins) {
+				t.Errorf("MinMaxHeap.PollMin() order = %v, want %v", gotMins, tt.wantMins)
+			}
+			if !reflect.DeepEqual(gotMaxs, tt.wantMaxs) {
+				t.Errorf("MinMaxHeap.PollMax() order = %v, want %v", gotMaxs, tt.wantMaxs)
+			}
+		})
+	}
+}
+
+func TestMinMaxHeap_empty(t *testing.T) {
+	h := NewMinMaxHeapOf[string]()
+	if _, err := h.PeekMin(); err == nil {
+		t.Errorf("MinMaxHeap.PeekMin() error = nil on an empty heap")
+	}
+	if _, err := h.PeekMax(); err == nil {
+		t.Errorf("MinMaxHeap.PeekMax() error = nil on an empty heap")
+	}
+	if _, err := h.PollMin(); err == nil {
+		t.Errorf("MinMaxHeap.PollMin() error = nil on an empty heap")
+	}
+	if _, err := h.PollMax(); err == nil {
+		t.Errorf("MinMaxHeap.PollMax() error = nil on an empty heap")
+	}
+}
+
+func TestMinMaxHeap_random(t *testing.T) {
+	type job struct {
+		name     string
+		priority int
+	}
+	random := rand.New(rand.NewSource(9))
+	h := NewMinMaxHeap(func(a, b job) bool { return a.priority < b.priority })
+	priorities := []int{}
+	for i := 0; i < 5000; i++ {
+		switch operation := random.Intn(4); {
+		case operation < 2 || len(priorities) == 0:
+			priority := random.Intn(100)
+			h.Insert(job{name: "job", priority: priority})
+			priorities = append(priorities, priority)
+			sort.Ints(priorities)
+		case operation == 2:
+			got, err := h.PollMin()
+			if err != nil || got.priority != priorities[0] {
+				t.Fatalf("MinMaxHeap.PollMin() = %v, %v, want %v", got, err, priorities[0])
+			}
+			priorities = priorities[1:]
+		default:
+			got, err := h.PollMax()
+			if err != nil || got.priority != priorities[len(priorities)-1] {
+				t.Fatalf("MinMaxHeap.PollMax() = %v, %v, want %v", got, err, priorities[len(priorities)-1])
+			}
+			priorities = priorities[:len(priorities)-1]
+		}
+		validateMinMaxHeap(t, h)
+		if h.Size() != len(priorities) {
+			t.Fatalf("MinMaxHeap.Size() = %v, want %v", h.Size(), len(priorities))
+		}
+		if len(priorities) > 0 {
+			min, _ := h.PeekMin()
+			max, _ := h.PeekMax()
+			if min.priority != priorities[0] || max.priority != priorities[len(priorities)-1] {
+				t.Fatalf("MinMaxHeap.PeekMin(), PeekMax() = %v, %v, want %v, %v",
+					min.priority, max.priority, priorities[0], priorities[len(priorities)-1])
+			}
+		}
+	}
+}
@@ -1,44 +1,149 @@
 package datastructures
 
+import (
+	"errors"
+	"sort"
+)
+
+// MinPriorityQueueHandle is a value queued in a min-priority queue, it is
+// returned by Enqueue and used to remove the value or change its priority.
+type MinPriorityQueueHandle[V any] struct {
+	value    V
+	priority float64
+	// sequence is the order in which the value was enqueued.
+	sequence uint64
+	// queue is nil once the value is dequeued or removed.
+	queue      *MinPriorityQueue[V]
+	heapHandle HeapHandle[*MinPriorityQueueHandle[V]]
+}
+
+// Value returns the value of the handle.
+func (handle *MinPriorityQueueHandle[V]) Value() V {
+	return handle.value
+}
+
+// Priority returns the priority of the value.
+func (handle *MinPriorityQueueHandle[V]) Priority() float64 {
+	return handle.priority
+}
+
 // MinPriorityQueue represents a min-priority queue data
 // structure.
-type MinPriorityQueue struct {
-	minHeap *MinHeap
+type MinPriorityQueue[V any] struct {
+	minHeap *Heap[*MinPriorityQueueHandle[V]]
+	// stable is true if values with equal priorities are dequeued in the
+	// order they were enqueued.
+	stable   bool
+	sequence uint64
 }
 
-// NewMinPriorityQueue returns a min-priority queue data structure.
-func NewMinPriorityQueue() *MinPriorityQueue {
-	return &MinPriorityQueue{
-		minHeap: NewMinHeap(),
-	}
+// NewMinPriorityQueue returns a min-priority queue data structure, values
+// with equal priorities are dequeued in any order.
+func NewMinPriorityQueue[V any]() *MinPriorityQueue[V] {
+	q := &MinPriorityQueue[V]{}
+	q.minHeap = NewHeap(q.less)
+	return q
 }
 
-// Enqueue adds an item to the priority queue.
-func (q *MinPriorityQueue) Enqueue(item float64) *MinPriorityQueue {
-	q.minHeap.Insert(item)
+// NewStableMinPriorityQueue returns a min-priority queue data structure
+// that dequeues values with equal priorities in the order they were
+// enqueued.
+func NewStableMinPriorityQueue[V any]() *MinPriorityQueue[V] {
+	q := NewMinPriorityQueue[V]()
+	q.stable = true
 	return q
 }
 
-// Dequeue removes the smallest item from the priority queue.
-func (q *MinPriorityQueue) Dequeue() (float64, error) {
-	return q.minHeap.Poll()
+// Enqueue adds a value to the priority queue and returns its handle.
+func (q *MinPriorityQueue[V]) Enqueue(value V, priority float64) *MinPriorityQueueHandle[V] {
+	handle := &MinPriorityQueueHandle[V]{value: value, priority: priority, queue: q}
+	q.push(handle)
+	return handle
+}
+
+// Dequeue removes the value with the smallest priority from the priority
+// queue.
+func (q *MinPriorityQueue[V]) Dequeue() (V, float64, error) {
+	handle, err := q.minHeap.Poll()
+	if err != nil {
+		var zero V
+		return zero, 0, errors.New("queue is empty")
+	}
+	handle.queue = nil
+	return handle.value, handle.priority, nil
+}
+
+// Peek returns the value with the smallest priority without removing it.
+func (q *MinPriorityQueue[V]) Peek() (V, float64, error) {
+	handle, err := q.minHeap.Peek()
+	if err != nil {
+		var zero V
+		return zero, 0, errors.New("queue is empty")
+	}
+	return handle.value, handle.priority, nil
+}
+
+// Update changes the priority of the value of handle, in a stable queue
+// the value is dequeued after the values that already have that priority.
+func (q *MinPriorityQueue[V]) Update(handle *MinPriorityQueueHandle[V], priority float64) error {
+	if !q.Contains(handle) {
+		return ErrInvalidHandle
+	}
+	if err := q.minHeap.Delete(handle.heapHandle); err != nil {
+		return err
+	}
+	handle.priority = priority
+	q.push(handle)
+	return nil
+}
+
+// Remove removes the value of handle from the priority queue.
+func (q *MinPriorityQueue[V]) Remove(handle *MinPriorityQueueHandle[V]) error {
+	if !q.Contains(handle) {
+		return ErrInvalidHandle
+	}
+	if err := q.minHeap.Delete(handle.heapHandle); err != nil {
+		return err
+	}
+	handle.queue = nil
+	return nil
 }
 
 // Size returns the size of the priority queue.
-func (q *MinPriorityQueue) Size() int {
+func (q *MinPriorityQueue[V]) Size() int {
 	return q.minHeap.Size()
 }
 
-// Contains returns true if the item is in the queue; else false.
-func (q *MinPriorityQueue) Contains(item float64) bool {
-	return q.minHeap.Contains(item)
+// Contains returns true if the value of handle is in the queue; else false.
+func (q *MinPriorityQueue[V]) Contains(handle *MinPriorityQueueHandle[V]) bool {
+	return handle != nil && handle.queue == q
+}
+
+// Iterate iterates through the queue in priority order and executes the
+// callback function f for each iteration.
+//
+// time complexity: O(n log n)
+func (q *MinPriorityQueue[V]) Iterate(f func(index int, value V, priority float64)) {
+	handles := append([]*MinPriorityQueueHandle[V]{}, q.minHeap.GetList()...)
+	sort.Slice(handles, func(i, j int) bool {
+		return q.less(handles[i], handles[j])
+	})
+	for k, handle := range handles {
+		f(k, handle.value, handle.priority)
+	}
+}
+
+// push adds handle to the heap with the next sequence.
+func (q *MinPriorityQueue[V]) push(handle *MinPriorityQueueHandle[V]) {
+	handle.sequence = q.sequence
+	q.sequence++
+	handle.heapHandle = q.minHeap.Push(handle)
 }
 
-// Iterate iterates through the queue and executes the callback function
-// f for each iteration.
-func (q *MinPriorityQueue) Iterate(f func(index int, item float64)) {
-	list := q.minHeap.GetList()
-	for k, v := range list {
-		f(k, v)
+// less orders the handles by priority, and by sequence in a stable queue.
+func (q *MinPriorityQueue[V]) less(a, b *MinPriorityQueueHandle[V]) bool {
+	if a.priority != b.priority || !q.stable {
+		return a.priority < b.priority
 	}
+	return a.sequence < b.sequence
 }
@@ -1,111 +1,190 @@
 package datastructures
 
 import (
+	"errors"
+	"reflect"
 	"testing"
 )
 
+type priorityQueueItem struct {
+	value    string
+	priority float64
+}
+
 func TestMinPriorityQueue_Enqueue(t *testing.T) {
-	type args struct {
-		item float64
-	}
 	tests := []struct {
-		name string
-		args args
+		name     string
+		value    string
+		priority float64
 	}{
 		{
-			name: "inserting item to priority queue",
-			args: args{item: 109},
+			name:     "inserting item to priority queue",
+			value:    "first",
+			priority: 109,
 		},
 		{
-			name: "inserting another item to the priority queue",
-			args: args{item: 10989},
+			name:     "inserting another item to the priority queue",
+			value:    "second",
+			priority: 10989,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			q := NewMinPriorityQueue()
-			q.Enqueue(tt.args.item)
+			q := NewMinPriorityQueue[string]()
+			handle := q.Enqueue(tt.value, tt.priority)
 			if q.Size() != 1 {
 				t.Errorf("MinPriorityQueue.Enqueue() = %v, want %v", q.Size(), 1)
 			}
+			if handle.Value() != tt.value || handle.Priority() != tt.priority {
+				t.Errorf("MinPriorityQueue.Enqueue() handle = %v, %v, want %v, %v",
+					handle.Value(), handle.Priority(), tt.value, tt.priority)
+			}
 		})
 	}
 }
 
 func TestMinPriorityQueue_Dequeue(t *testing.T) {
 	tests := []struct {
-		name    string
-		items   []float64
-		want    float64
-		wantErr bool
+		name         string
+		items        []priorityQueueItem
+		want         string
+		wantPriority float64
+		wantErr      bool
 	}{
 		{
-			name:  "dequeuing an item from 6 items queue",
-			items: []float64{6, 7, 3, 6, 2, 1},
-			want:  1,
+			name: "dequeuing an item from 6 items queue",
+			items: []priorityQueueItem{
+				{"a", 6}, {"b", 7}, {"c", 3}, {"d", 6}, {"e", 2}, {"f", 1},
+			},
+			want:         "f",
+			wantPriority: 1,
+		},
+		{
+			name:         "dequeuing an item from 1 item queue",
+			items:        []priorityQueueItem{{"a", 300}},
+			want:         "a",
+			wantPriority: 300,
 		},
 		{
-			name:  "dequeuing an item from 1 item queue",
-			items: []float64{300},
-			want:  300,
+			name:    "dequeuing from an empty queue",
+			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			q := NewMinPriorityQueue()
-			for _, i := range tt.items {
-				q.Enqueue(i)
+			q := NewMinPriorityQueue[string]()
+			for _, item := range tt.items {
+				q.Enqueue(item.value, item.priority)
 			}
-			got, err := q.Dequeue()
+			got, priority, err := q.Dequeue()
 			if (err != nil) != tt.wantErr {
 				t.Errorf("MinPriorityQueue.Dequeue() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if got != tt.want {
-				t.Errorf("MinPriorityQueue.Dequeue() = %v, want %v", got, tt.want)
+			if got != tt.want || priority != tt.wantPriority {
+				t.Errorf("MinPriorityQueue.Dequeue() = %v, %v, want %v, %v", got, priority, tt.want, tt.wantPriority)
 			}
-			if q.Size() != len(tt.items)-1 {
+			if !tt.wantErr && q.Size() != len(tt.items)-1 {
 				t.Errorf("MinPriorityQueue.Dequeue() = %v, want %v", q.Size(), len(tt.items)-1)
 			}
 		})
 	}
 }
 
-func TestMinPriorityQueue_Contains(t *testing.T) {
-	type args struct {
-		item float64
+func TestMinPriorityQueue_stable(t *testing.T) {
+	items := []priorityQueueItem{
+		{"a", 2}, {"b", 1}, {"c", 2}, {"d", 1}, {"e", 2}, {"f", 0}, {"g", 1}, {"h", 2},
+	}
+	q := NewStableMinPriorityQueue[string]()
+	for _, item := range items {
+		q.Enqueue(item.value, item.priority)
+	}
+	got := []string{}
+	for q.Size() > 0 {
+		value, _, _ := q.Dequeue()
+		got = append(got, value)
+	}
+	want := []string{"f", "b", "d", "g", "a", "c", "e", "h"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MinPriorityQueue.Dequeue() order = %v, want %v", got, want)
+	}
+}
+
+func TestMinPriorityQueue_Update(t *testing.T) {
+	q := NewStableMinPriorityQueue[string]()
+	a := q.Enqueue("a", 1)
+	b := q.Enqueue("b", 2)
+	c := q.Enqueue("c", 3)
+	if err := q.Update(c, 0); err != nil {
+		t.Fatalf("MinPriorityQueue.Update() error = %v", err)
+	}
+	// a is queued behind b, which already has priority 2.
+	if err := q.Update(a, 2); err != nil {
+		t.Fatalf("MinPriorityQueue.Update() error = %v", err)
+	}
+	if c.Priority() != 0 {
+		t.Errorf("MinPriorityQueueHandle.Priority() = %v, want %v", c.Priority(), 0)
+	}
+	got := []string{}
+	for q.Size() > 0 {
+		value, _, _ := q.Dequeue()
+		got = append(got, value)
+	}
+	want := []string{"c", "b", "a"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MinPriorityQueue.Dequeue() order = %v, want %v", got, want)
+	}
+	if err := q.Update(b, 5); !errors.Is(err, ErrInvalidHandle) {
+		t.Errorf("MinPriorityQueue.Update() error = %v, wantErr %v", err, ErrInvalidHandle)
+	}
+}
+
+func TestMinPriorityQueue_Remove(t *testing.T) {
+	q := NewMinPriorityQueue[int]()
+	handles := []*MinPriorityQueueHandle[int]{}
+	for i := 0; i < 6; i++ {
+		handles = append(handles, q.Enqueue(i, float64(10-i)))
 	}
+	for _, i := range []int{5, 2} {
+		if err := q.Remove(handles[i]); err != nil {
+			t.Fatalf("MinPriorityQueue.Remove() error = %v", err)
+		}
+	}
+	if err := q.Remove(handles[2]); !errors.Is(err, ErrInvalidHandle) {
+		t.Errorf("MinPriorityQueue.Remove() twice error = %v, wantErr %v", err, ErrInvalidHandle)
+	}
+	if err := NewMinPriorityQueue[int]().Remove(handles[0]); !errors.Is(err, ErrInvalidHandle) {
+		t.Errorf("MinPriorityQueue.Remove() with a handle of another queue error = %v, wantErr %v", err, ErrInvalidHandle)
+	}
+	got := []int{}
+	for q.Size() > 0 {
+		value, _, _ := q.Dequeue()
+		got = append(got, value)
+	}
+	want := []int{4, 3, 1, 0}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MinPriorityQueue.Dequeue() order = %v, want %v", got, want)
+	}
+}
+
+func TestMinPriorityQueue_Contains(t *testing.T) {
+	q := NewMinPriorityQueue[string]()
+	queued := q.Enqueue("queued", 1)
+	dequeued := q.Enqueue("dequeued", 0)
+	q.Dequeue()
 	tests := []struct {
-		name  string
-		items []float64
-		args  args
-		want  bool
+		name   string
+		handle *MinPriorityQueueHandle[string]
+		want   bool
 	}{
-		{
-			name:  "invalid item",
-			items: []float64{440, 2009444, 990029},
-			args:  args{item: 445},
-			want:  false,
-		},
-		{
-			name:  "valid item",
-			items: []float64{111, 222, 333, 444},
-			args:  args{item: 333},
-			want:  true,
-		},
-		{
-			name: "invalid item in empty queue",
-			args: args{item: 1233},
-			want: false,
-		},
+		{name: "queued item", handle: queued, want: true},
+		{name: "dequeued item", handle: dequeued},
+		{name: "item of another queue", handle: NewMinPriorityQueue[string]().Enqueue("other", 1)},
+		{name: "nil handle"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			q := NewMinPriorityQueue()
-			for _, i := range tt.items {
-				q.Enqueue(i)
-			}
-			if got := q.Contains(tt.args.item); got != tt.want {
+			if got := q.Contains(tt.handle); got != tt.want {
 				t.Errorf("MinPriorityQueue.Contains() = %v, want %v", got, tt.want)
 			}
 		})
@@ -115,34 +194,48 @@ func TestMinPriorityQueue_Contains(t *testing.T) {
 func TestMinPriorityQueue_Iterate(t *testing.T) {
 	tests := []struct {
 		name  string
-		items []float64
-		want  []float64
+		items []priorityQueueItem
+		want  []priorityQueueItem
 	}{
 		{
 			name:  "queue with 5 items",
-			items: []float64{8, 4, 5, 3, 6},
-			want:  []float64{3, 4, 5, 8, 6},
+			items: []priorityQueueItem{{"a", 8}, {"b", 4}, {"c", 5}, {"d", 3}, {"e", 6}},
+			want:  []priorityQueueItem{{"d", 3}, {"b", 4}, {"c", 5}, {"e", 6}, {"a", 8}},
+		},
+		{
+			name:  "equal priorities",
+			items: []priorityQueueItem{{"a", 1}, {"b", 0}, {"c", 1}, {"d", 1}},
+			want:  []priorityQueueItem{{"b", 0}, {"a", 1}, {"c", 1}, {"d", 1}},
 		},
 		{
 			name: "empty queue",
+			want: []priorityQueueItem{},
 		},
 		{
 			name:  "queue with 1 item",
-			items: []float64{7},
-			want:  []float64{7},
+			items: []priorityQueueItem{{"a", 7}},
+			want:  []priorityQueueItem{{"a", 7}},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			q := NewMinPriorityQueue()
-			for _, i := range tt.items {
-				q.Enqueue(i)
+			q := NewStableMinPriorityQueue[string]()
+			for _, item := range tt.items {
+				q.Enqueue(item.value, item.priority)
 			}
-			q.Iterate(func(index int, item float64) {
-				if tt.want[index] != item {
-					t.Errorf("index: %d, expected = %v, got = %v", index, tt.want[index], item)
+			got := []priorityQueueItem{}
+			q.Iterate(func(index int, value string, priority float64) {
+				if index != len(got) {
+					t.Errorf("MinPriorityQueue.Iterate() index = %v, want %v", index, len(got))
 				}
+				got = append(got, priorityQueueItem{value, priority})
 			})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MinPriorityQueue.Iterate() = %v, want %v", got, tt.want)
+			}
+			if q.Size() != len(tt.items) {
+				t.Errorf("MinPriorityQueue.Iterate() changed the size to %v", q.Size())
+			}
 		})
 	}
 }
@@ -0,0 +1,293 @@
+package datastructures
+
+import "fmt"
+
+const (
+	// openAddressingMinSize is the smallest number of slots of an open
+	// addressing hash table.
+	openAddressingMinSize       = 8
+	openAddressingMaxLoadFactor = 0.5
+)
+
+// KeyValueTable is the interface implemented by the hash table data
+// structures in this package.
+type KeyValueTable interface {
+	Set(key interface{}, value interface{}) error
+	Get(key interface{}) (interface{}, error)
+	Delete(key interface{})
+	Iterate(f func(key, value interface{}))
+	Size() int
+	Elements() int
+}
+
+// ProbingStrategy is the strategy used by an open addressing hash table
+// to find the next slot when a collision happens.
+type ProbingStrategy int
+
+const (
+	// LinearProbing checks the slots following the home slot one by one.
+	LinearProbing ProbingStrategy = iota
+	// QuadraticProbing checks the slots at triangular number offsets from
+	// the home slot.
+	QuadraticProbing
+	// DoubleHashing checks the slots at multiples of a second hash of
+	// the key from the home slot.
+	DoubleHashing
+)
+
+type openAddressingSlotState uint8
+
+const (
+	slotEmpty openAddressingSlotState = iota
+	slotOccupied
+	// slotDeleted marks a slot whose entry was deleted (tombstone), it
+	// must not stop a lookup but can be reused by an insert.
+	slotDeleted
+)
+
+type openAddressingSlot struct {
+	key   interface{}
+	value interface{}
+	hash  uint64
+	state openAddressingSlotState
+}
+
+// OpenAddressingHashTable represents a hash table data structure that
+// stores its entries directly in an array of slots.
+//
+// the number of slots is always a power of two and the table doubles in
+// size when the load factor (including deleted slots) goes above the max
+// load factor, 0.5 by default.
+type OpenAddressingHashTable struct {
+	slots         []openAddressingSlot
+	elementsCount int
+	deletedCount  int
+	probing       ProbingStrategy
+	maxLoadFactor float64
+	hasher        Hasher
+	seed          uint64
+}
+
+// NewOpenAddressingHashTable returns a new open addressing hash table
+// that uses the probing strategy to resolve collisions.
+//
+// size is rounded up to the next power of two.
+func NewOpenAddressingHashTable(size int, probing ProbingStrategy, options ...HashTableOption) *OpenAddressingHashTable {
+	config := newHashTableConfig(0, openAddressingMaxLoadFactor, options)
+	return &OpenAddressingHashTable{
+		slots:         make([]openAddressingSlot, openAddressingCapacity(size)),
+		probing:       probing,
+		maxLoadFactor: openAddressingLoadFactor(config.maxLoadFactor, openAddressingMaxLoadFactor),
+		hasher:        config.hasher,
+		seed:          config.seed,
+	}
+}
+
+// NewLinearProbingHashTable returns a new open addressing hash table that
+// uses linear probing.
+func NewLinearProbingHashTable(size int, options ...HashTableOption) *OpenAddressingHashTable {
+	return NewOpenAddressingHashTable(size, LinearProbing, options...)
+}
+
+// NewQuadraticProbingHashTable returns a new open addressing hash table that
+// uses quadratic probing.
+func NewQuadraticProbingHashTable(size int, options ...HashTableOption) *OpenAddressingHashTable {
+	return NewOpenAddressingHashTable(size, QuadraticProbing, options...)
+}
+
+// NewDoubleHashingHashTable returns a new open addressing hash table that
+// uses double hashing.
+func NewDoubleHashingHashTable(size int, options ...HashTableOption) *OpenAddressingHashTable {
+	return NewOpenAddressingHashTable(size, DoubleHashing, options...)
+}
+
+// Set sets a new <Key, Value> item in the hash table.
+func (h *OpenAddressingHashTable) Set(key interface{}, value interface{}) error {
+	hash, err := h.hash(key)
+	if err != nil {
+		return err
+	}
+	if index := h.find(hash, key); index >= 0 {
+		h.slots[index].value = value
+		return nil
+	}
+	if float64(h.elementsCount+h.deletedCount+1) > h.maxLoadFactor*float64(len(h.slots)) {
+		newSize := len(h.slots)
+		// rehashing into a table of the same size is enough when most of
+		// the used slots are deleted slots.
+		if float64(h.elementsCount+1) > h.maxLoadFactor*float64(len(h.slots))/2 {
+			newSize *= 2
+		}
+		h.resize(newSize)
+	}
+	h.insert(hash, key, value)
+	return nil
+}
+
+// Get retrieves an item from the hash table using the key.
+func (h *OpenAddressingHashTable) Get(key interface{}) (interface{}, error) {
+	hash, err := h.hash(key)
+	if err != nil {
+		return nil, err
+	}
+	index := h.find(hash, key)
+	if index < 0 {
+		return nil, fmt.Errorf("%w: %v", ErrKeyNotFound, key)
+	}
+	return h.slots[index].value, nil
+}
+
+// Delete removes an item from the hash table in key position.
+//
+// if there is no item at key position, delete does nothing.
+func (h *OpenAddressingHashTable) Delete(key interface{}) {
+	hash, err := h.hash(key)
+	if err != nil {
+		return
+	}
+	index := h.find(hash, key)
+	if index < 0 {
+		return
+	}
+	h.slots[index] = openAddressingSlot{state: slotDeleted}
+	h.elementsCount--
+	h.deletedCount++
+}
+
+// Size returns the number of slots in the hash table.
+func (h *OpenAddressingHashTable) Size() int {
+	return len(h.slots)
+}
+
+// Elements returns the number of elements in the hash table.
+func (h *OpenAddressingHashTable) Elements() int {
+	return h.elementsCount
+}
+
+// Iterate iterates through the hash table and executes the callback function
+// f for each iteration.
+func (h *OpenAddressingHashTable) Iterate(f func(key, value interface{})) {
+	for _, slot := range h.slots {
+		if slot.state == slotOccupied {
+			f(slot.key, slot.value)
+		}
+	}
+}
+
+// Stats returns the cluster and probe statistics of the hash table.
+func (h *OpenAddressingHashTable) Stats() HashTableStats {
+	stats := newHashTableStats(len(h.slots))
+	for index, slot := range h.slots {
+		if slot.state != slotOccupied {
+			continue
+		}
+		probes := 1
+		for h.probe(slot.hash, probes-1) != index {
+			probes++
+		}
+		stats.addProbes(probes)
+	}
+	openAddressingClusters(&stats, len(h.slots), func(index int) bool {
+		return h.slots[index].state == slotOccupied
+	})
+	return stats.finish()
+}
+
+// probe returns the slot index of the i-th probe for hash.
+func (h *OpenAddressingHashTable) probe(hash uint64, i int) int {
+	mask := uint64(len(h.slots) - 1)
+	offset := uint64(i)
+	switch h.probing {
+	case QuadraticProbing:
+		// triangular numbers visit every slot of a power of two table.
+		offset = uint64(i) * uint64(i+1) / 2
+	case DoubleHashing:
+		// an odd step is coprime with a power of two table size so every
+		// slot is visited.
+		offset = uint64(i) * (mixHash(hash) | 1)
+	}
+	return int((hash + offset) & mask)
+}
+
+// find is a helper method that returns the slot index of key or -1 if
+// the key is not in the hash table.
+func (h *OpenAddressingHashTable) find(hash uint64, key interface{}) int {
+	for i := 0; i < len(h.slots); i++ {
+		index := h.probe(hash, i)
+		slot := h.slots[index]
+		if slot.state == slotEmpty {
+			return -1
+		}
+		if slot.state == slotOccupied && slot.hash == hash && h.hasher.Equal(slot.key, key) {
+			return index
+		}
+	}
+	return -1
+}
+
+// insert is a helper method that stores a key that is not in the hash
+// table in the first free slot of its probe sequence.
+func (h *OpenAddressingHashTable) insert(hash uint64, key, value interface{}) {
+	for i := 0; i < len(h.slots); i++ {
+		index := h.probe(hash, i)
+		slot := &h.slots[index]
+		if slot.state == slotOccupied {
+			continue
+		}
+		if slot.state == slotDeleted {
+			h.deletedCount--
+		}
+		*slot = openAddressingSlot{key: key, value: value, hash: hash, state: slotOccupied}
+		h.elementsCount++
+		return
+	}
+}
+
+// resize is a helper method that moves every element to a new array of
+// newSize slots, dropping the deleted slots.
+func (h *OpenAddressingHashTable) resize(newSize int) {
+	oldSlots := h.slots
+	h.slots = make([]openAddressingSlot, newSize)
+	h.elementsCount = 0
+	h.deletedCount = 0
+	for _, slot := range oldSlots {
+		if slot.state == slotOccupied {
+			h.insert(slot.hash, slot.key, slot.value)
+		}
+	}
+}
+
+// hash is a helper method that returns the hash of key.
+func (h *OpenAddressingHashTable) hash(key interface{}) (uint64, error) {
+	return openAddressingHash(h.hasher, key, h.seed)
+}
+
+// openAddressingHash is a helper function that returns the hash of key
+// mixed so that its lower bits can be used as a slot index, even when the
+// hasher returns poorly distributed hashes.
+func openAddressingHash(hasher Hasher, key interface{}, seed uint64) (uint64, error) {
+	hash, err := hasher.Hash(key, seed)
+	if err != nil {
+		return 0, err
+	}
+	return mixHash(hash), nil
+}
+
+// openAddressingLoadFactor is a helper function that returns loadFactor if
+// it can be used by an open addressing hash table, else defaultLoadFactor.
+func openAddressingLoadFactor(loadFactor, defaultLoadFactor float64) float64 {
+	if loadFactor <= 0 || loadFactor >= 1 {
+		return defaultLoadFactor
+	}
+	return loadFactor
+}
+
+// openAddressingCapacity is a helper function that returns the smallest
+// power of two that is at least size.
+func openAddressingCapacity(size int) int {
+	capacity := openAddressingMinSize
+	for capacity < size {
+		capacity *= 2
+	}
+	return capacity
+}
@@ -0,0 +1,264 @@
+package datastructures
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+// keyValueTables returns a constructor for every hash table implementation
+// in the package.
+func keyValueTables() map[string]func(size int) KeyValueTable {
+	return map[string]func(size int) KeyValueTable{
+		"chaining":          func(size int) KeyValueTable { return NewHashTable(size) },
+		"linear probing":    func(size int) KeyValueTable { return NewLinearProbingHashTable(size) },
+		"quadratic probing": func(size int) KeyValueTable { return NewQuadraticProbingHashTable(size) },
+		"double hashing":    func(size int) KeyValueTable { return NewDoubleHashingHashTable(size) },
+		"robin hood":        func(size int) KeyValueTable { return NewRobinHoodHashTable(size) },
+		"concurrent":        func(size int) KeyValueTable { return NewConcurrentHashTable(size, 4) },
+		"cuckoo":            func(size int) KeyValueTable { return NewCuckooHashTable(size, 0, 0) },
+		"linked":            func(size int) KeyValueTable { return NewLinkedHashTable(size) },
+	}
+}
+
+func TestKeyValueTable_SetGetDelete(t *testing.T) {
+	type item struct {
+		key   interface{}
+		value interface{}
+	}
+	tests := []struct {
+		name       string
+		items      []item
+		deleteKeys []interface{}
+		want       map[interface{}]interface{}
+	}{
+		{
+			name: "string keys",
+			items: []item{
+				{key: "1 key", value: "1st"},
+				{key: "2 key", value: "2nd"},
+				{key: "3 key", value: "3rd"},
+			},
+			want: map[interface{}]interface{}{"1 key": "1st", "2 key": "2nd", "3 key": "3rd"},
+		},
+		{
+			name: "updating existing keys",
+			items: []item{
+				{key: 1, value: "1st"},
+				{key: 1, value: "updated"},
+				{key: 2.5, value: "2nd"},
+			},
+			want: map[interface{}]interface{}{1: "updated", 2.5: "2nd"},
+		},
+		{
+			name: "deleting keys",
+			items: []item{
+				{key: 1, value: "1st"},
+				{key: 2, value: "2nd"},
+				{key: 3, value: "3rd"},
+			},
+			deleteKeys: []interface{}{2, 4, "invalid"},
+			want:       map[interface{}]interface{}{1: "1st", 3: "3rd"},
+		},
+		{
+			name:       "empty table",
+			deleteKeys: []interface{}{1},
+			want:       map[interface{}]interface{}{},
+		},
+	}
+	for tableName, newTable := range keyValueTables() {
+		for _, tt := range tests {
+			t.Run(tableName+" "+tt.name, func(t *testing.T) {
+				h := newTable(2)
+				for _, item := range tt.items {
+					if err := h.Set(item.key, item.value); err != nil {
+						t.Fatalf("Set() error = %v", err)
+					}
+				}
+				for _, key := range tt.deleteKeys {
+					h.Delete(key)
+				}
+				if h.Elements() != len(tt.want) {
+					t.Errorf("Elements() = %v, want %v", h.Elements(), len(tt.want))
+				}
+				for key, value := range tt.want {
+					got, err := h.Get(key)
+					if err != nil || got != value {
+						t.Errorf("Get(%v) = %v, %v, want %v", key, got, err, value)
+					}
+				}
+				for _, key := range tt.deleteKeys {
+					if _, err := h.Get(key); err == nil {
+						t.Errorf("Get(%v) error = %v, wantErr %v", key, err, true)
+					}
+				}
+				result := map[interface{}]interface{}{}
+				h.Iterate(func(key, value interface{}) {
+					result[key] = value
+				})
+				if !reflect.DeepEqual(result, tt.want) {
+					t.Errorf("Iterate() = %v, want %v", result, tt.want)
+				}
+			})
+		}
+	}
+}
+
+func TestKeyValueTable_invalidKey(t *testing.T) {
+	for tableName, newTable := range keyValueTables() {
+		t.Run(tableName, func(t *testing.T) {
+			h := newTable(4)
+			if err := h.Set([]int{1}, "value"); err == nil {
+				t.Errorf("Set() error = %v, wantErr %v", err, true)
+			}
+			if _, err := h.Get(nil); err == nil {
+				t.Errorf("Get() error = %v, wantErr %v", err, true)
+			}
+			h.Delete(nil)
+			if h.Elements() != 0 {
+				t.Errorf("Elements() = %v, want %v", h.Elements(), 0)
+			}
+		})
+	}
+}
+
+func TestKeyValueTable_manyItems(t *testing.T) {
+	for tableName, newTable := range keyValueTables() {
+		t.Run(tableName, func(t *testing.T) {
+			h := newTable(4)
+			for i := 0; i < 1000; i++ {
+				h.Set(i, i)
+				h.Set("key "+strconv.Itoa(i), i)
+			}
+			for i := 0; i < 1000; i += 2 {
+				h.Delete(i)
+			}
+			if h.Elements() != 1500 {
+				t.Errorf("Elements() = %v, want %v", h.Elements(), 1500)
+			}
+			for i := 0; i < 1000; i++ {
+				_, err := h.Get(i)
+				if (err != nil) != (i%2 == 0) {
+					t.Errorf("Get(%v) error = %v", i, err)
+				}
+				if got, _ := h.Get("key " + strconv.Itoa(i)); got != i {
+					t.Errorf("Get(%v) = %v, want %v", "key "+strconv.Itoa(i), got, i)
+				}
+			}
+		})
+	}
+}
+
+func TestOpenAddressingHashTable_probe(t *testing.T) {
+	tests := []struct {
+		name    string
+		probing ProbingStrategy
+	}{
+		{name: "linear probing", probing: LinearProbing},
+		{name: "quadratic probing", probing: QuadraticProbing},
+		{name: "double hashing", probing: DoubleHashing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewOpenAddressingHashTable(64, tt.probing)
+			for _, hash := range []uint64{0, 7, 12345, 1 << 40} {
+				visited := []int{}
+				for i := 0; i < h.Size(); i++ {
+					visited = append(visited, h.probe(hash, i))
+				}
+				sort.Ints(visited)
+				// every slot must be visited exactly once.
+				for index, slot := range visited {
+					if index != slot {
+						t.Fatalf("probe(%v) does not visit every slot: %v", hash, visited)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestOpenAddressingHashTable_deletedSlots(t *testing.T) {
+	h := NewLinearProbingHashTable(8)
+	// setting and deleting keys repeatedly must not grow the table
+	// because the deleted slots are reused or dropped.
+	for i := 0; i < 100; i++ {
+		h.Set(i, i)
+		h.Delete(i)
+	}
+	if h.Size() != 8 {
+		t.Errorf("Size() = %v, want %v", h.Size(), 8)
+	}
+	if h.Elements() != 0 {
+		t.Errorf("Elements() = %v, want %v", h.Elements(), 0)
+	}
+}
+
+func TestNewOpenAddressingHashTable(t *testing.T) {
+	tests := []struct {
+		name string
+		size int
+		want int
+	}{
+		{name: "size below the minimum", size: 0, want: 8},
+		{name: "power of two size", size: 32, want: 32},
+		{name: "size rounded up", size: 33, want: 64},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewLinearProbingHashTable(tt.size).Size(); got != tt.want {
+				t.Errorf("NewOpenAddressingHashTable() size = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func benchmarkKeyValueTables(b *testing.B, f func(b *testing.B, h KeyValueTable)) {
+	names := []string{}
+	tables := keyValueTables()
+	for name := range tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		newTable := tables[name]
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			f(b, newTable(16))
+		})
+	}
+}
+
+func BenchmarkKeyValueTable_Set(b *testing.B) {
+	benchmarkKeyValueTables(b, func(b *testing.B, h KeyValueTable) {
+		for i := 0; i < b.N; i++ {
+			h.Set(i, i)
+		}
+	})
+}
+
+func BenchmarkKeyValueTable_Get(b *testing.B) {
+	benchmarkKeyValueTables(b, func(b *testing.B, h KeyValueTable) {
+		for i := 0; i < 10000; i++ {
+			h.Set(i, i)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			h.Get(i % 10000)
+		}
+	})
+}
+
+func BenchmarkKeyValueTable_SetDelete(b *testing.B) {
+	benchmarkKeyValueTables(b, func(b *testing.B, h KeyValueTable) {
+		for i := 0; i < 1000; i++ {
+			h.Set(i, i)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			h.Set(1000+i, i)
+			h.Delete(i)
+		}
+	})
+}
@@ -0,0 +1,204 @@
+package datastructures
+
+import (
+	"errors"
+	"fmt"
+)
+
+// pairingHeapNode is a node of a pairing heap, it is the HeapHandle
+// returned by Insert.
+type pairingHeapNode[T any] struct {
+	item T
+	// child is the first child of the node.
+	child *pairingHeapNode[T]
+	// sibling is the next sibling of the node.
+	sibling *pairingHeapNode[T]
+	// previous is the previous sibling of the node or its parent if it is
+	// the first child.
+	previous *pairingHeapNode[T]
+	// owner is nil once the node is removed.
+	owner *heapOwner
+}
+
+// Item returns the item of the node.
+func (node *pairingHeapNode[T]) Item() T {
+	return node.item
+}
+
+// PairingHeap represents a pairing heap data structure, a heap-ordered
+// multiway tree whose root is the smallest item.
+//
+// Insert, Meld and DecreaseKey are O(1) and ExtractMin and Delete are
+// O(log n) amortized.
+type PairingHeap[T any] struct {
+	root  *pairingHeapNode[T]
+	size  int
+	less  func(a, b T) bool
+	owner *heapOwner
+}
+
+// NewPairingHeap returns a new pairing heap data structure ordered by less.
+func NewPairingHeap[T any](less func(a, b T) bool) *PairingHeap[T] {
+	return &PairingHeap[T]{less: less, owner: &heapOwner{}}
+}
+
+// Insert adds a new item to the heap and returns its handle.
+func (h *PairingHeap[T]) Insert(item T) HeapHandle[T] {
+	node := &pairingHeapNode[T]{item: item, owner: h.owner}
+	h.root = h.link(h.root, node)
+	h.size++
+	return node
+}
+
+// Push adds a new item to the heap, it is the same as Insert.
+func (h *PairingHeap[T]) Push(item T) HeapHandle[T] {
+	return h.Insert(item)
+}
+
+// Min returns the smallest item of the heap without removing it.
+func (h *PairingHeap[T]) Min() (T, error) {
+	if h.root == nil {
+		var zero T
+		return zero, errors.New("heap is empty")
+	}
+	return h.root.item, nil
+}
+
+// ExtractMin removes the smallest item from the heap.
+func (h *PairingHeap[T]) ExtractMin() (T, error) {
+	if h.root == nil {
+		var zero T
+		return zero, errors.New("heap is empty")
+	}
+	root := h.root
+	h.root = h.mergePairs(root.child)
+	h.size--
+	root.child, root.owner = nil, nil
+	return root.item, nil
+}
+
+// DecreaseKey replaces the item of handle with item, which must not be
+// greater than the current item.
+//
+// the node is cut from its parent and linked with the root.
+func (h *PairingHeap[T]) DecreaseKey(handle HeapHandle[T], item T) error {
+	node, err := h.node(handle)
+	if err != nil {
+		return err
+	}
+	if h.less(node.item, item) {
+		return fmt.Errorf("%w: %v is greater than %v", ErrInvalidDecrease, item, node.item)
+	}
+	node.item = item
+	if node != h.root {
+		h.cut(node)
+		h.root = h.link(h.root, node)
+	}
+	return nil
+}
+
+// Delete removes the item of handle from the heap.
+func (h *PairingHeap[T]) Delete(handle HeapHandle[T]) error {
+	node, err := h.node(handle)
+	if err != nil {
+		return err
+	}
+	if node == h.root {
+		_, err := h.ExtractMin()
+		return err
+	}
+	h.cut(node)
+	h.root = h.link(h.root, h.mergePairs(node.child))
+	h.size--
+	node.child, node.owner = nil, nil
+	return nil
+}
+
+// Meld moves the items of other, which must be a *PairingHeap[T], to the
+// heap in O(1), other is emptied.
+func (h *PairingHeap[T]) Meld(other PriorityQueue[T]) error {
+	o, ok := other.(*PairingHeap[T])
+	if !ok {
+		return fmt.Errorf("%w: %T and %T", ErrIncompatibleHeap, h, other)
+	}
+	if o == h {
+		return nil
+	}
+	h.root = h.link(h.root, o.root)
+	h.size += o.size
+	o.owner.melded = h.owner
+	*o = *NewPairingHeap(o.less)
+	return nil
+}
+
+// Size returns the number of items in the heap.
+func (h *PairingHeap[T]) Size() int {
+	return h.size
+}
+
+// node returns handle as a node of the heap.
+func (h *PairingHeap[T]) node(handle HeapHandle[T]) (*pairingHeapNode[T], error) {
+	node, ok := handle.(*pairingHeapNode[T])
+	if !ok || node.owner == nil || node.owner.find() != h.owner {
+		return nil, ErrInvalidHandle
+	}
+	return node, nil
+}
+
+// link makes the root with the larger item the first child of the other
+// one and returns the new root.
+func (h *PairingHeap[T]) link(a, b *pairingHeapNode[T]) *pairingHeapNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if h.less(b.item, a.item) {
+		a, b = b, a
+	}
+	b.sibling = a.child
+	if a.child != nil {
+		a.child.previous = b
+	}
+	b.previous = a
+	a.child = b
+	return a
+}
+
+// cut removes node and its subtree from its parent.
+func (h *PairingHeap[T]) cut(node *pairingHeapNode[T]) {
+	if node.previous.child == node {
+		node.previous.child = node.sibling
+	} else {
+		node.previous.sibling = node.sibling
+	}
+	if node.sibling != nil {
+		node.sibling.previous = node.previous
+	}
+	node.sibling, node.previous = nil, nil
+}
+
+// mergePairs links the siblings starting at first in pairs from left to
+// right, then links the pairs from right to left and returns the new root.
+func (h *PairingHeap[T]) mergePairs(first *pairingHeapNode[T]) *pairingHeapNode[T] {
+	var pairs []*pairingHeapNode[T]
+	for first != nil {
+		a, b := first, first.sibling
+		first = nil
+		if b != nil {
+			first = b.sibling
+			b.sibling, b.previous = nil, nil
+		}
+		a.sibling, a.previous = nil, nil
+		pairs = append(pairs, h.link(a, b))
+	}
+	var root *pairingHeapNode[T]
+	for i := len(pairs) - 1; i >= 0; i-- {
+		root = h.link(pairs[i], root)
+	}
+	if root != nil {
+		root.previous = nil
+	}
+	return root
+}
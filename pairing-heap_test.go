@@ -0,0 +1,75 @@
+package datastructures
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// validatePairingHeap is a test helper that checks the heap order and the
+// links of every node and returns the number of nodes.
+func validatePairingHeap[T any](t *testing.T, h *PairingHeap[T]) int {
+	t.Helper()
+	if h.root == nil {
+		return 0
+	}
+	if h.root.previous != nil || h.root.sibling != nil {
+		t.Fatalf("PairingHeap root has a previous node or a sibling")
+	}
+	count := 0
+	var walk func(node *pairingHeapNode[T])
+	walk = func(node *pairingHeapNode[T]) {
+		count++
+		previous := node
+		for child := node.child; child != nil; child = child.sibling {
+			if child.previous != previous {
+				t.Fatalf("PairingHeap node %v has a wrong previous node", child.item)
+			}
+			if h.less(child.item, node.item) {
+				t.Fatalf("PairingHeap node %v is less than its parent %v", child.item, node.item)
+			}
+			walk(child)
+			previous = child
+		}
+	}
+	walk(h.root)
+	return count
+}
+
+func TestPairingHeap_structure(t *testing.T) {
+	tests := []struct {
+		name      string
+		items     int
+		decreases int
+		deletes   int
+		extracts  int
+	}{
+		{name: "inserts", items: 100},
+		{name: "inserts and extracts", items: 100, extracts: 60},
+		{name: "decreases and deletes", items: 200, decreases: 150, deletes: 50, extracts: 30},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			random := rand.New(rand.NewSource(5))
+			h := NewPairingHeap(func(a, b int) bool { return a < b })
+			handles := []HeapHandle[int]{}
+			for i := 0; i < tt.items; i++ {
+				handles = append(handles, h.Insert(random.Intn(1000)))
+			}
+			for i := 0; i < tt.decreases; i++ {
+				handle := handles[random.Intn(len(handles))]
+				h.DecreaseKey(handle, handle.Item()-random.Intn(500))
+			}
+			random.Shuffle(len(handles), func(i, j int) { handles[i], handles[j] = handles[j], handles[i] })
+			for _, handle := range handles[:tt.deletes] {
+				h.Delete(handle)
+			}
+			for i := 0; i < tt.extracts; i++ {
+				h.ExtractMin()
+			}
+			want := tt.items - tt.deletes - tt.extracts
+			if got := validatePairingHeap(t, h); got != want || h.Size() != want {
+				t.Errorf("PairingHeap has %v nodes and Size() = %v, want %v", got, h.Size(), want)
+			}
+		})
+	}
+}
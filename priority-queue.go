@@ -0,0 +1,64 @@
+package datastructures
+
+import "errors"
+
+var (
+	// ErrInvalidHandle is returned when a handle does not belong to a heap
+	// or its item was removed.
+	ErrInvalidHandle = errors.New("invalid heap handle")
+	// ErrInvalidDecrease is returned when DecreaseKey is called with an item
+	// that is greater than the current item.
+	ErrInvalidDecrease = errors.New("item is greater than the current item")
+	// ErrIncompatibleHeap is returned when heaps of different types are
+	// melded.
+	ErrIncompatibleHeap = errors.New("incompatible heap")
+)
+
+// HeapHandle is a reference to an item of a heap returned by Push, it is
+// used to change or remove the item.
+type HeapHandle[T any] interface {
+	// Item returns the current item. once the item is removed by
+	// ExtractMin or Delete, it returns the item as it was removed.
+	Item() T
+}
+
+// PriorityQueue is the interface implemented by the heaps of this package
+// that can change their items in place and be melded.
+type PriorityQueue[T any] interface {
+	// Push adds an item and returns its handle.
+	Push(item T) HeapHandle[T]
+	// Min returns the smallest item without removing it.
+	Min() (T, error)
+	// ExtractMin removes the smallest item.
+	ExtractMin() (T, error)
+	// DecreaseKey replaces the item of handle with a smaller or equal item.
+	DecreaseKey(handle HeapHandle[T], item T) error
+	// Delete removes the item of handle.
+	Delete(handle HeapHandle[T]) error
+	// Meld moves every item of other, which must have the same type, to
+	// the heap. the handles of other stay valid.
+	Meld(other PriorityQueue[T]) error
+	// Size returns the number of items.
+	Size() int
+}
+
+// heapOwner identifies the heap that a node belongs to.
+//
+// melding a heap into another one points its owner to the owner of the
+// other heap, so that the nodes do not have to be updated.
+type heapOwner struct {
+	melded *heapOwner
+}
+
+// find returns the owner that o was melded into, it shortens the chain of
+// owners on the way.
+func (o *heapOwner) find() *heapOwner {
+	root := o
+	for root.melded != nil {
+		root = root.melded
+	}
+	for o != root {
+		o, o.melded = o.melded, root
+	}
+	return root
+}
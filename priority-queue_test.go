@@ -0,0 +1,277 @@
+package datastructures
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// priorityQueues returns an empty min-ordered int heap of every
+// PriorityQueue implementation.
+func priorityQueues() map[string]func() PriorityQueue[int] {
+	less := func(a, b int) bool { return a < b }
+	return map[string]func() PriorityQueue[int]{
+		"binary":    func() PriorityQueue[int] { return NewHeap(less) },
+		"pairing":   func() PriorityQueue[int] { return NewPairingHeap(less) },
+		"binomial":  func() PriorityQueue[int] { return NewBinomialHeap(less) },
+		"fibonacci": func() PriorityQueue[int] { return NewFibonacciHeap(less) },
+	}
+}
+
+// priorityQueueModel is a test helper that checks a PriorityQueue against
+// the handles and items it should hold.
+type priorityQueueModel struct {
+	queue   PriorityQueue[int]
+	handles []HeapHandle[int]
+	items   []int
+}
+
+func (m *priorityQueueModel) push(item int) {
+	m.handles = append(m.handles, m.queue.Push(item))
+	m.items = append(m.items, item)
+}
+
+func (m *priorityQueueModel) remove(index int) {
+	last := len(m.items) - 1
+	m.handles[index], m.items[index] = m.handles[last], m.items[last]
+	m.handles, m.items = m.handles[:last], m.items[:last]
+}
+
+func (m *priorityQueueModel) check(t *testing.T) {
+	t.Helper()
+	if m.queue.Size() != len(m.items) {
+		t.Fatalf("PriorityQueue.Size() = %v, want %v", m.queue.Size(), len(m.items))
+	}
+	for i, handle := range m.handles {
+		if handle.Item() != m.items[i] {
+			t.Fatalf("HeapHandle.Item() = %v, want %v", handle.Item(), m.items[i])
+		}
+	}
+	if len(m.items) == 0 {
+		return
+	}
+	min := m.items[0]
+	for _, item := range m.items {
+		if item < min {
+			min = item
+		}
+	}
+	if got, err := m.queue.Min(); err != nil || got != min {
+		t.Fatalf("PriorityQueue.Min() = %v, %v, want %v", got, err, min)
+	}
+}
+
+func TestPriorityQueue_random(t *testing.T) {
+	for name, newQueue := range priorityQueues() {
+		t.Run(name, func(t *testing.T) {
+			random := rand.New(rand.NewSource(11))
+			m := &priorityQueueModel{queue: newQueue()}
+			// the items are priority*10000+i so that equal priorities are
+			// still distinct items and ExtractMin removes a known handle.
+			for i := 0; i < 4000; i++ {
+				switch operation := random.Intn(10); {
+				case operation < 4 || len(m.items) == 0:
+					m.push(random.Intn(1000)*10000 + i)
+				case operation < 6:
+					got, err := m.queue.ExtractMin()
+					if err != nil {
+						t.Fatalf("PriorityQueue.ExtractMin() error = %v", err)
+					}
+					for index, item := range m.items {
+						if item == got {
+							m.remove(index)
+							break
+						}
+					}
+				case operation < 8:
+					index := random.Intn(len(m.items))
+					item := m.items[index] - random.Intn(100)*10000
+					if err := m.queue.DecreaseKey(m.handles[index], item); err != nil {
+						t.Fatalf("PriorityQueue.DecreaseKey() error = %v", err)
+					}
+					m.items[index] = item
+				default:
+					index := random.Intn(len(m.items))
+					if err := m.queue.Delete(m.handles[index]); err != nil {
+						t.Fatalf("PriorityQueue.Delete() error = %v", err)
+					}
+					m.remove(index)
+				}
+				m.check(t)
+			}
+			sort.Ints(m.items)
+			for _, want := range m.items {
+				if got, err := m.queue.ExtractMin(); err != nil || got != want {
+					t.Fatalf("PriorityQueue.ExtractMin() = %v, %v, want %v", got, err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestPriorityQueue_Meld(t *testing.T) {
+	for name, newQueue := range priorityQueues() {
+		t.Run(name, func(t *testing.T) {
+			m := &priorityQueueModel{queue: newQueue()}
+			other := &priorityQueueModel{queue: newQueue()}
+			for i := 0; i < 50; i++ {
+				m.push(i * 3)
+				other.push(i*3 + 1)
+			}
+			// the melded heap is used after one of its items was removed.
+			other.queue.ExtractMin()
+			other.remove(0)
+			if err := m.queue.Meld(other.queue); err != nil {
+				t.Fatalf("PriorityQueue.Meld() error = %v", err)
+			}
+			if other.queue.Size() != 0 {
+				t.Errorf("PriorityQueue.Size() of the melded heap = %v, want %v", other.queue.Size(), 0)
+			}
+			if err := other.queue.Delete(other.handles[0]); !errors.Is(err, ErrInvalidHandle) {
+				t.Errorf("PriorityQueue.Delete() on the melded heap error = %v, wantErr %v", err, ErrInvalidHandle)
+			}
+			// the handles of the melded heap now belong to the heap.
+			if err := m.queue.DecreaseKey(other.handles[10], -1); err != nil {
+				t.Fatalf("PriorityQueue.DecreaseKey() error = %v", err)
+			}
+			other.items[10] = -1
+			m.handles = append(m.handles, other.handles...)
+			m.items = append(m.items, other.items...)
+			m.check(t)
+			if err := m.queue.Meld(newQueue()); err != nil {
+				t.Errorf("PriorityQueue.Meld() with an empty heap error = %v", err)
+			}
+			m.check(t)
+		})
+	}
+}
+
+func TestPriorityQueue_errors(t *testing.T) {
+	for name, newQueue := range priorityQueues() {
+		t.Run(name, func(t *testing.T) {
+			q := newQueue()
+			if _, err := q.Min(); err == nil {
+				t.Errorf("PriorityQueue.Min() error = nil on an empty heap")
+			}
+			if _, err := q.ExtractMin(); err == nil {
+				t.Errorf("PriorityQueue.ExtractMin() error = nil on an empty heap")
+			}
+			handle := q.Push(5)
+			if err := q.DecreaseKey(handle, 6); !errors.Is(err, ErrInvalidDecrease) {
+				t.Errorf("PriorityQueue.DecreaseKey() error = %v, wantErr %v", err, ErrInvalidDecrease)
+			}
+			if err := q.DecreaseKey(handle, 5); err != nil {
+				t.Errorf("PriorityQueue.DecreaseKey() with an equal item error = %v", err)
+			}
+			other := newQueue()
+			if err := other.Delete(handle); !errors.Is(err, ErrInvalidHandle) {
+				t.Errorf("PriorityQueue.Delete() with a handle of another heap error = %v, wantErr %v", err, ErrInvalidHandle)
+			}
+			if err := q.Delete(handle); err != nil {
+				t.Fatalf("PriorityQueue.Delete() error = %v", err)
+			}
+			if err := q.Delete(handle); !errors.Is(err, ErrInvalidHandle) {
+				t.Errorf("PriorityQueue.Delete() with a removed handle error = %v, wantErr %v", err, ErrInvalidHandle)
+			}
+			if err := q.DecreaseKey(handle, 1); !errors.Is(err, ErrInvalidHandle) {
+				t.Errorf("PriorityQueue.DecreaseKey() with a removed handle error = %v, wantErr %v", err, ErrInvalidHandle)
+			}
+			for otherName, newOther := range priorityQueues() {
+				if otherName == name {
+					continue
+				}
+				if err := q.Meld(newOther()); !errors.Is(err, ErrIncompatibleHeap) {
+					t.Errorf("PriorityQueue.Meld(%v) error = %v, wantErr %v", otherName, err, ErrIncompatibleHeap)
+				}
+			}
+		})
+	}
+}
+
+func TestPriorityQueue_removedHandles(t *testing.T) {
+	for name, newQueue := range priorityQueues() {
+		t.Run(name, func(t *testing.T) {
+			q := newQueue()
+			five, three, eight := q.Push(5), q.Push(3), q.Push(8)
+			if _, err := q.ExtractMin(); err != nil {
+				t.Fatalf("PriorityQueue.ExtractMin() error = %v", err)
+			}
+			if err := q.Delete(eight); err != nil {
+				t.Fatalf("PriorityQueue.Delete() error = %v", err)
+			}
+			if err := q.DecreaseKey(five, 1); err != nil {
+				t.Fatalf("PriorityQueue.DecreaseKey() error = %v", err)
+			}
+			if _, err := q.ExtractMin(); err != nil {
+				t.Fatalf("PriorityQueue.ExtractMin() error = %v", err)
+			}
+			// the handles keep the item they had when it was removed.
+			for _, tt := range []struct {
+				handle HeapHandle[int]
+				want   int
+			}{{three, 3}, {eight, 8}, {five, 1}} {
+				if got := tt.handle.Item(); got != tt.want {
+					t.Errorf("HeapHandle.Item() of a removed item = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestHeapOwner_find(t *testing.T) {
+	owners := []*heapOwner{{}, {}, {}, {}}
+	for i := 0; i < len(owners)-1; i++ {
+		owners[i].melded = owners[i+1]
+	}
+	if got := owners[0].find(); got != owners[3] {
+		t.Errorf("heapOwner.find() = %p, want %p", got, owners[3])
+	}
+	if owners[0].melded != owners[3] || owners[1].melded != owners[3] {
+		t.Errorf("heapOwner.find() did not shorten the chain of owners")
+	}
+}
+
+func BenchmarkPriorityQueue_dijkstra(b *testing.B) {
+	// a random graph where every node has 8 edges, the shortest distances
+	// from node 0 are computed with decrease-key.
+	const nodes = 20000
+	random := rand.New(rand.NewSource(1))
+	edges := make([][][2]int, nodes)
+	for node := range edges {
+		for i := 0; i < 8; i++ {
+			edges[node] = append(edges[node], [2]int{random.Intn(nodes), random.Intn(100) + 1})
+		}
+	}
+	for name, newQueue := range priorityQueues() {
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				// the items are distance*nodes+node, so that the node of the
+				// smallest item is known.
+				q := newQueue()
+				handles := make([]HeapHandle[int], nodes)
+				distances := make([]int, nodes)
+				for node := range distances {
+					distances[node] = -1
+				}
+				handles[0] = q.Push(0)
+				for q.Size() > 0 {
+					item, _ := q.ExtractMin()
+					node, distance := item%nodes, item/nodes
+					distances[node] = distance
+					for _, edge := range edges[node] {
+						next, total := edge[0], distance+edge[1]
+						if distances[next] != -1 {
+							continue
+						}
+						if handles[next] == nil {
+							handles[next] = q.Push(total*nodes + next)
+						} else if total*nodes+next < handles[next].Item() {
+							q.DecreaseKey(handles[next], total*nodes+next)
+						}
+					}
+				}
+			}
+		})
+	}
+}
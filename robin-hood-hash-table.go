@@ -0,0 +1,185 @@
+package datastructures
+
+import "fmt"
+
+const robinHoodMaxLoadFactor = 0.75
+
+type robinHoodSlot struct {
+	key   interface{}
+	value interface{}
+	hash  uint64
+	// distance is how far the slot is from the home slot of its key.
+	distance int
+	occupied bool
+}
+
+// RobinHoodHashTable represents an open addressing hash table data
+// structure that uses robin hood hashing.
+//
+// on insert, an entry takes the slot of any entry that is closer to its
+// home slot, which keeps the probe lengths short and even. deletes shift
+// the following entries back instead of leaving deleted slots behind.
+type RobinHoodHashTable struct {
+	slots         []robinHoodSlot
+	elementsCount int
+	maxLoadFactor float64
+	hasher        Hasher
+	seed          uint64
+}
+
+// NewRobinHoodHashTable returns a new robin hood hash table.
+//
+// size is rounded up to the next power of two.
+func NewRobinHoodHashTable(size int, options ...HashTableOption) *RobinHoodHashTable {
+	config := newHashTableConfig(0, robinHoodMaxLoadFactor, options)
+	return &RobinHoodHashTable{
+		slots:         make([]robinHoodSlot, openAddressingCapacity(size)),
+		maxLoadFactor: openAddressingLoadFactor(config.maxLoadFactor, robinHoodMaxLoadFactor),
+		hasher:        config.hasher,
+		seed:          config.seed,
+	}
+}
+
+// Set sets a new <Key, Value> item in the hash table.
+func (h *RobinHoodHashTable) Set(key interface{}, value interface{}) error {
+	hash, err := openAddressingHash(h.hasher, key, h.seed)
+	if err != nil {
+		return err
+	}
+	if index := h.find(hash, key); index >= 0 {
+		h.slots[index].value = value
+		return nil
+	}
+	if float64(h.elementsCount+1) > h.maxLoadFactor*float64(len(h.slots)) {
+		h.resize(len(h.slots) * 2)
+	}
+	h.insert(robinHoodSlot{key: key, value: value, hash: hash, occupied: true})
+	return nil
+}
+
+// Get retrieves an item from the hash table using the key.
+func (h *RobinHoodHashTable) Get(key interface{}) (interface{}, error) {
+	hash, err := openAddressingHash(h.hasher, key, h.seed)
+	if err != nil {
+		return nil, err
+	}
+	index := h.find(hash, key)
+	if index < 0 {
+		return nil, fmt.Errorf("%w: %v", ErrKeyNotFound, key)
+	}
+	return h.slots[index].value, nil
+}
+
+// Delete removes an item from the hash table in key position.
+//
+// if there is no item at key position, delete does nothing.
+func (h *RobinHoodHashTable) Delete(key interface{}) {
+	hash, err := openAddressingHash(h.hasher, key, h.seed)
+	if err != nil {
+		return
+	}
+	index := h.find(hash, key)
+	if index < 0 {
+		return
+	}
+	// backward shift deletion: the following entries that are not in
+	// their home slot are moved one slot back.
+	mask := len(h.slots) - 1
+	next := (index + 1) & mask
+	for h.slots[next].occupied && h.slots[next].distance > 0 {
+		h.slots[index] = h.slots[next]
+		h.slots[index].distance--
+		index = next
+		next = (next + 1) & mask
+	}
+	h.slots[index] = robinHoodSlot{}
+	h.elementsCount--
+}
+
+// Size returns the number of slots in the hash table.
+func (h *RobinHoodHashTable) Size() int {
+	return len(h.slots)
+}
+
+// Elements returns the number of elements in the hash table.
+func (h *RobinHoodHashTable) Elements() int {
+	return h.elementsCount
+}
+
+// Iterate iterates through the hash table and executes the callback function
+// f for each iteration.
+func (h *RobinHoodHashTable) Iterate(f func(key, value interface{})) {
+	for _, slot := range h.slots {
+		if slot.occupied {
+			f(slot.key, slot.value)
+		}
+	}
+}
+
+// Stats returns the cluster and probe statistics of the hash table.
+func (h *RobinHoodHashTable) Stats() HashTableStats {
+	stats := newHashTableStats(len(h.slots))
+	for _, slot := range h.slots {
+		if slot.occupied {
+			stats.addProbes(slot.distance + 1)
+		}
+	}
+	openAddressingClusters(&stats, len(h.slots), func(index int) bool {
+		return h.slots[index].occupied
+	})
+	return stats.finish()
+}
+
+// find is a helper method that returns the slot index of key or -1 if
+// the key is not in the hash table.
+func (h *RobinHoodHashTable) find(hash uint64, key interface{}) int {
+	mask := len(h.slots) - 1
+	index := int(hash & uint64(mask))
+	for distance := 0; distance < len(h.slots); distance++ {
+		slot := h.slots[index]
+		// the key would have taken this slot if it was in the table.
+		if !slot.occupied || slot.distance < distance {
+			return -1
+		}
+		if slot.hash == hash && h.hasher.Equal(slot.key, key) {
+			return index
+		}
+		index = (index + 1) & mask
+	}
+	return -1
+}
+
+// insert is a helper method that stores an entry whose key is not in
+// the hash table.
+func (h *RobinHoodHashTable) insert(entry robinHoodSlot) {
+	mask := len(h.slots) - 1
+	index := int(entry.hash & uint64(mask))
+	entry.distance = 0
+	for {
+		slot := &h.slots[index]
+		if !slot.occupied {
+			*slot = entry
+			h.elementsCount++
+			return
+		}
+		// taking the slot from an entry that is closer to its home slot.
+		if slot.distance < entry.distance {
+			*slot, entry = entry, *slot
+		}
+		index = (index + 1) & mask
+		entry.distance++
+	}
+}
+
+// resize is a helper method that moves every element to a new array of
+// newSize slots.
+func (h *RobinHoodHashTable) resize(newSize int) {
+	oldSlots := h.slots
+	h.slots = make([]robinHoodSlot, newSize)
+	h.elementsCount = 0
+	for _, slot := range oldSlots {
+		if slot.occupied {
+			h.insert(slot)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package datastructures
+
+import (
+	"testing"
+)
+
+// checkRobinHoodDistances fails the test if a slot distance does not match
+// the position of the slot relative to its home slot, or if an entry is
+// separated from its home slot by an empty slot.
+func checkRobinHoodDistances(t *testing.T, h *RobinHoodHashTable) {
+	t.Helper()
+	mask := len(h.slots) - 1
+	for index, slot := range h.slots {
+		if !slot.occupied {
+			continue
+		}
+		home := int(slot.hash & uint64(mask))
+		if (home+slot.distance)&mask != index {
+			t.Fatalf("slot %d has distance %d, home slot is %d", index, slot.distance, home)
+		}
+		for d := 1; d <= slot.distance; d++ {
+			if !h.slots[(index-d)&mask].occupied {
+				t.Fatalf("slot %d is separated from its home slot %d by an empty slot", index, home)
+			}
+		}
+	}
+}
+
+func TestRobinHoodHashTable_Delete(t *testing.T) {
+	tests := []struct {
+		name       string
+		items      int
+		deleteKeys []int
+	}{
+		{
+			name:       "deleting a few keys",
+			items:      50,
+			deleteKeys: []int{3, 17, 25, 49},
+		},
+		{
+			name:       "deleting every other key",
+			items:      200,
+			deleteKeys: []int{0, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26, 28, 30},
+		},
+		{
+			name:       "deleting a missing key",
+			items:      10,
+			deleteKeys: []int{100},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewRobinHoodHashTable(8)
+			for i := 0; i < tt.items; i++ {
+				h.Set(i, i)
+			}
+			checkRobinHoodDistances(t, h)
+			deleted := map[int]bool{}
+			for _, key := range tt.deleteKeys {
+				h.Delete(key)
+				if key < tt.items {
+					deleted[key] = true
+				}
+				checkRobinHoodDistances(t, h)
+			}
+			if h.Elements() != tt.items-len(deleted) {
+				t.Errorf("RobinHoodHashTable.Elements() = %v, want %v", h.Elements(), tt.items-len(deleted))
+			}
+			for i := 0; i < tt.items; i++ {
+				_, err := h.Get(i)
+				if (err != nil) != deleted[i] {
+					t.Errorf("RobinHoodHashTable.Get(%v) error = %v, wantErr %v", i, err, deleted[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRobinHoodHashTable_Size(t *testing.T) {
+	tests := []struct {
+		name  string
+		items int
+		want  int
+	}{
+		{name: "empty table", items: 0, want: 8},
+		{name: "table at the load factor", items: 6, want: 8},
+		{name: "grown table", items: 7, want: 16},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewRobinHoodHashTable(8)
+			for i := 0; i < tt.items; i++ {
+				h.Set(i, i)
+			}
+			if got := h.Size(); got != tt.want {
+				t.Errorf("RobinHoodHashTable.Size() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,270 @@
+package datastructures
+
+import (
+	"math/rand"
+)
+
+// skipListMaxLevel is the maximum number of levels a skip list
+// node can have.
+const skipListMaxLevel = 32
+
+// defaultSkipListProbability is the level probability used when an
+// invalid probability is passed to NewSkipList.
+const defaultSkipListProbability = 0.5
+
+// SkipList represents a skip list data structure.
+//
+// this skip list implementation keeps its items sorted by key and
+// stores the width (span) of every link so rank queries can be
+// answered in O(log n).
+type SkipList struct {
+	head        *SkipListNode
+	level       int
+	length      int
+	probability float64
+	random      *rand.Rand
+}
+
+// SkipListNode is the node used in the skip list data structure.
+type SkipListNode struct {
+	Key   float64
+	Value interface{}
+	next  []*SkipListNode
+	// span holds the number of level 0 nodes crossed when following
+	// the link at the same level in next.
+	span []int
+}
+
+// NewSkipList returns a new skip list data structure.
+//
+// probability is the chance of a node being promoted to the next level,
+// it must be between 0 and 1 (exclusive) else 0.5 is used.
+// seed is used to seed the random level generator so that the shape of
+// the skip list is deterministic for the same sequence of operations.
+func NewSkipList(probability float64, seed int64) *SkipList {
+	if probability <= 0 || probability >= 1 {
+		probability = defaultSkipListProbability
+	}
+	return &SkipList{
+		head: &SkipListNode{
+			next: make([]*SkipListNode, skipListMaxLevel),
+			span: make([]int, skipListMaxLevel),
+		},
+		level:       1,
+		probability: probability,
+		random:      rand.New(rand.NewSource(seed)),
+	}
+}
+
+// randomLevel is a helper method to generate the level of a new node.
+func (s *SkipList) randomLevel() int {
+	level := 1
+	for level < skipListMaxLevel && s.random.Float64() < s.probability {
+		level++
+	}
+	return level
+}
+
+// Insert adds a new <Key, Value> item to the skip list.
+//
+// if the key already exist, the value is updated.
+func (s *SkipList) Insert(key float64, value interface{}) *SkipList {
+	update := make([]*SkipListNode, skipListMaxLevel)
+	rank := make([]int, skipListMaxLevel)
+	trav := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		if i != s.level-1 {
+			rank[i] = rank[i+1]
+		}
+		for trav.next[i] != nil && trav.next[i].Key < key {
+			rank[i] += trav.span[i]
+			trav = trav.next[i]
+		}
+		update[i] = trav
+	}
+	if trav.next[0] != nil && trav.next[0].Key == key {
+		trav.next[0].Value = value
+		return s
+	}
+
+	level := s.randomLevel()
+	if level > s.level {
+		for i := s.level; i < level; i++ {
+			rank[i] = 0
+			update[i] = s.head
+			update[i].span[i] = s.length
+		}
+		s.level = level
+	}
+	node := &SkipListNode{
+		Key:   key,
+		Value: value,
+		next:  make([]*SkipListNode, level),
+		span:  make([]int, level),
+	}
+	for i := 0; i < level; i++ {
+		node.next[i] = update[i].next[i]
+		update[i].next[i] = node
+		node.span[i] = update[i].span[i] - (rank[0] - rank[i])
+		update[i].span[i] = (rank[0] - rank[i]) + 1
+	}
+	// levels above the new node now skip one more node.
+	for i := level; i < s.level; i++ {
+		update[i].span[i]++
+	}
+	s.length++
+	return s
+}
+
+// Search walks through the skip list to look for the specified key.
+//
+// it returns nil if the key does not exist.
+func (s *SkipList) Search(key float64) *SkipListNode {
+	trav := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for trav.next[i] != nil && trav.next[i].Key < key {
+			trav = trav.next[i]
+		}
+	}
+	trav = trav.next[0]
+	if trav != nil && trav.Key == key {
+		return trav
+	}
+	return nil
+}
+
+// Delete removes the item with the specified key from the skip list.
+//
+// it returns false if the key does not exist.
+func (s *SkipList) Delete(key float64) bool {
+	update := make([]*SkipListNode, skipListMaxLevel)
+	trav := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for trav.next[i] != nil && trav.next[i].Key < key {
+			trav = trav.next[i]
+		}
+		update[i] = trav
+	}
+	node := trav.next[0]
+	if node == nil || node.Key != key {
+		return false
+	}
+	for i := 0; i < s.level; i++ {
+		if update[i].next[i] == node {
+			update[i].span[i] += node.span[i] - 1
+			update[i].next[i] = node.next[i]
+			continue
+		}
+		update[i].span[i]--
+	}
+	for s.level > 1 && s.head.next[s.level-1] == nil {
+		s.level--
+	}
+	s.length--
+	return true
+}
+
+// Floor returns the node with the largest key less than or equal
+// to key.
+//
+// it returns nil if there is no such node.
+func (s *SkipList) Floor(key float64) *SkipListNode {
+	trav := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for trav.next[i] != nil && trav.next[i].Key <= key {
+			trav = trav.next[i]
+		}
+	}
+	if trav == s.head {
+		return nil
+	}
+	return trav
+}
+
+// Ceiling returns the node with the smallest key greater than or
+// equal to key.
+//
+// it returns nil if there is no such node.
+func (s *SkipList) Ceiling(key float64) *SkipListNode {
+	trav := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for trav.next[i] != nil && trav.next[i].Key < key {
+			trav = trav.next[i]
+		}
+	}
+	return trav.next[0]
+}
+
+// Rank returns the zero-based position of key in the skip list.
+//
+// it returns -1 if the key does not exist.
+func (s *SkipList) Rank(key float64) int {
+	rank := 0
+	trav := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for trav.next[i] != nil && trav.next[i].Key <= key {
+			rank += trav.span[i]
+			trav = trav.next[i]
+		}
+	}
+	if trav != s.head && trav.Key == key {
+		return rank - 1
+	}
+	return -1
+}
+
+// GetByRank returns the node at the zero-based position rank.
+//
+// it returns nil if rank is out of range.
+func (s *SkipList) GetByRank(rank int) *SkipListNode {
+	if rank < 0 || rank >= s.length {
+		return nil
+	}
+	target := rank + 1
+	traversed := 0
+	trav := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for trav.next[i] != nil && traversed+trav.span[i] <= target {
+			traversed += trav.span[i]
+			trav = trav.next[i]
+		}
+		if traversed == target {
+			return trav
+		}
+	}
+	return nil
+}
+
+// Range executes the callback function f for every node with a key
+// between from and to (inclusive) in ascending order.
+func (s *SkipList) Range(from, to float64, f func(node *SkipListNode)) {
+	trav := s.Ceiling(from)
+	for trav != nil && trav.Key <= to {
+		next := trav.next[0]
+		f(trav)
+		trav = next
+	}
+}
+
+// Iterate iterates through the skip list in ascending order and executes
+// the callback function f for each iteration.
+func (s *SkipList) Iterate(f func(index int, node *SkipListNode)) {
+	trav := s.head.next[0]
+	index := 0
+	for trav != nil {
+		next := trav.next[0]
+		f(index, trav)
+		trav = next
+		index++
+	}
+}
+
+// Size returns the number of items in the skip list.
+func (s *SkipList) Size() int {
+	return s.length
+}
+
+// IsEmpty returns true if the skip list is empty else false.
+func (s *SkipList) IsEmpty() bool {
+	return s.length == 0
+}
@@ -0,0 +1,323 @@
+package datastructures
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func skipListKeys(s *SkipList) []float64 {
+	keys := []float64{}
+	s.Iterate(func(_ int, node *SkipListNode) {
+		keys = append(keys, node.Key)
+	})
+	return keys
+}
+
+func TestNewSkipList(t *testing.T) {
+	tests := []struct {
+		name        string
+		probability float64
+		want        float64
+	}{
+		{
+			name:        "valid probability",
+			probability: 0.25,
+			want:        0.25,
+		},
+		{
+			name:        "zero probability",
+			probability: 0,
+			want:        0.5,
+		},
+		{
+			name:        "probability of one",
+			probability: 1,
+			want:        0.5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSkipList(tt.probability, 1)
+			if s.probability != tt.want {
+				t.Errorf("NewSkipList() probability = %v, want %v", s.probability, tt.want)
+			}
+		})
+	}
+}
+
+func TestSkipList_Insert(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []float64
+		want  []float64
+	}{
+		{
+			name:  "unordered items",
+			items: []float64{5, 1, 9, 3, 7},
+			want:  []float64{1, 3, 5, 7, 9},
+		},
+		{
+			name:  "duplicate items",
+			items: []float64{4, 2, 4, 2, 8},
+			want:  []float64{2, 4, 8},
+		},
+		{
+			name: "no items",
+			want: []float64{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSkipList(0.5, 1)
+			for _, item := range tt.items {
+				s.Insert(item, item*10)
+			}
+			if got := skipListKeys(s); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SkipList.Insert() = %v, want %v", got, tt.want)
+			}
+			if s.Size() != len(tt.want) {
+				t.Errorf("SkipList.Size() = %v, want %v", s.Size(), len(tt.want))
+			}
+		})
+	}
+
+	t.Run("updating existing key", func(t *testing.T) {
+		s := NewSkipList(0.5, 1)
+		s.Insert(1, "first").Insert(1, "updated")
+		if got := s.Search(1).Value; got != "updated" {
+			t.Errorf("SkipList.Insert() = %v, want %v", got, "updated")
+		}
+	})
+}
+
+func TestSkipList_Search(t *testing.T) {
+	tests := []struct {
+		name      string
+		items     []float64
+		key       float64
+		want      interface{}
+		wantFound bool
+	}{
+		{
+			name:      "existing key",
+			items:     []float64{10, 20, 30},
+			key:       20,
+			want:      20.0,
+			wantFound: true,
+		},
+		{
+			name:  "non-existing key",
+			items: []float64{10, 20, 30},
+			key:   25,
+		},
+		{
+			name: "empty skip list",
+			key:  1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSkipList(0.5, 1)
+			for _, item := range tt.items {
+				s.Insert(item, item)
+			}
+			node := s.Search(tt.key)
+			if (node != nil) != tt.wantFound {
+				t.Fatalf("SkipList.Search() = %v, wantFound %v", node, tt.wantFound)
+			}
+			if node != nil && node.Value != tt.want {
+				t.Errorf("SkipList.Search() = %v, want %v", node.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestSkipList_Delete(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []float64
+		key   float64
+		want  bool
+		keys  []float64
+	}{
+		{
+			name:  "existing key",
+			items: []float64{3, 1, 2},
+			key:   2,
+			want:  true,
+			keys:  []float64{1, 3},
+		},
+		{
+			name:  "non-existing key",
+			items: []float64{3, 1, 2},
+			key:   4,
+			keys:  []float64{1, 2, 3},
+		},
+		{
+			name: "empty skip list",
+			key:  4,
+			keys: []float64{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSkipList(0.5, 1)
+			for _, item := range tt.items {
+				s.Insert(item, nil)
+			}
+			if got := s.Delete(tt.key); got != tt.want {
+				t.Errorf("SkipList.Delete() = %v, want %v", got, tt.want)
+			}
+			if got := skipListKeys(s); !reflect.DeepEqual(got, tt.keys) {
+				t.Errorf("SkipList.Delete() keys = %v, want %v", got, tt.keys)
+			}
+		})
+	}
+}
+
+func TestSkipList_FloorCeiling(t *testing.T) {
+	tests := []struct {
+		name        string
+		key         float64
+		wantFloor   interface{}
+		wantCeiling interface{}
+	}{
+		{
+			name:        "exact key",
+			key:         20,
+			wantFloor:   20.0,
+			wantCeiling: 20.0,
+		},
+		{
+			name:        "key between items",
+			key:         25,
+			wantFloor:   20.0,
+			wantCeiling: 30.0,
+		},
+		{
+			name:        "key below all items",
+			key:         5,
+			wantCeiling: 10.0,
+		},
+		{
+			name:      "key above all items",
+			key:       35,
+			wantFloor: 30.0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSkipList(0.5, 1)
+			s.Insert(10, nil).Insert(20, nil).Insert(30, nil)
+			var floor, ceiling interface{}
+			if node := s.Floor(tt.key); node != nil {
+				floor = node.Key
+			}
+			if node := s.Ceiling(tt.key); node != nil {
+				ceiling = node.Key
+			}
+			if floor != tt.wantFloor {
+				t.Errorf("SkipList.Floor() = %v, want %v", floor, tt.wantFloor)
+			}
+			if ceiling != tt.wantCeiling {
+				t.Errorf("SkipList.Ceiling() = %v, want %v", ceiling, tt.wantCeiling)
+			}
+		})
+	}
+}
+
+func TestSkipList_Range(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to float64
+		want     []float64
+	}{
+		{
+			name: "inner range",
+			from: 2,
+			to:   4,
+			want: []float64{2, 3, 4},
+		},
+		{
+			name: "range between items",
+			from: 2.5,
+			to:   4.5,
+			want: []float64{3, 4},
+		},
+		{
+			name: "empty range",
+			from: 7,
+			to:   9,
+			want: []float64{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSkipList(0.5, 1)
+			for _, item := range []float64{5, 4, 3, 2, 1} {
+				s.Insert(item, nil)
+			}
+			got := []float64{}
+			s.Range(tt.from, tt.to, func(node *SkipListNode) {
+				got = append(got, node.Key)
+			})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SkipList.Range() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSkipList_Rank(t *testing.T) {
+	s := NewSkipList(0.5, 42)
+	keys := []float64{}
+	for i := 0; i < 200; i++ {
+		key := float64((i * 37) % 200)
+		keys = append(keys, key)
+		s.Insert(key, nil)
+	}
+	// deleting some keys to make sure the spans are kept in sync.
+	for i := 0; i < 200; i += 3 {
+		s.Delete(float64(i))
+	}
+	expected := []float64{}
+	for _, key := range keys {
+		if int(key)%3 != 0 {
+			expected = append(expected, key)
+		}
+	}
+	sort.Float64s(expected)
+
+	for rank, key := range expected {
+		if got := s.Rank(key); got != rank {
+			t.Errorf("SkipList.Rank(%v) = %v, want %v", key, got, rank)
+		}
+		if node := s.GetByRank(rank); node == nil || node.Key != key {
+			t.Errorf("SkipList.GetByRank(%v) = %v, want %v", rank, node, key)
+		}
+	}
+	if got := s.Rank(0); got != -1 {
+		t.Errorf("SkipList.Rank() = %v, want %v", got, -1)
+	}
+	if got := s.GetByRank(len(expected)); got != nil {
+		t.Errorf("SkipList.GetByRank() = %v, want %v", got, nil)
+	}
+}
+
+func TestSkipList_deterministicSeed(t *testing.T) {
+	levels := func(seed int64) []int {
+		s := NewSkipList(0.5, seed)
+		for i := 0; i < 50; i++ {
+			s.Insert(float64(i), nil)
+		}
+		result := []int{}
+		s.Iterate(func(_ int, node *SkipListNode) {
+			result = append(result, len(node.next))
+		})
+		return result
+	}
+	if !reflect.DeepEqual(levels(7), levels(7)) {
+		t.Errorf("NewSkipList() with the same seed produced different levels")
+	}
+}
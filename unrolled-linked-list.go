@@ -0,0 +1,189 @@
+package datastructures
+
+import "errors"
+
+// defaultUnrolledLinkedListBlockSize is the block size used when an
+// invalid block size is passed to NewUnrolledLinkedList.
+const defaultUnrolledLinkedListBlockSize = 64
+
+// UnrolledLinkedList represents an unrolled linked list data structure.
+//
+// this linked list implementation stores several elements in a fixed
+// size block per node, which reduces the pointer overhead per element
+// compared to the DoublyLinkedList.
+type UnrolledLinkedList struct {
+	head      *unrolledLinkedListNode
+	tail      *unrolledLinkedListNode
+	blockSize int
+	length    int
+}
+
+// unrolledLinkedListNode is the node used in the unrolled linked list,
+// the elements of a node are stored in items[start : start+count].
+type unrolledLinkedListNode struct {
+	items    []interface{}
+	start    int
+	count    int
+	next     *unrolledLinkedListNode
+	previous *unrolledLinkedListNode
+}
+
+// NewUnrolledLinkedList returns a new unrolled linked list.
+//
+// blockSize is the maximum number of elements stored in a single node,
+// if it is less than 1 a default block size of 64 is used.
+func NewUnrolledLinkedList(blockSize int) *UnrolledLinkedList {
+	if blockSize < 1 {
+		blockSize = defaultUnrolledLinkedListBlockSize
+	}
+	return &UnrolledLinkedList{blockSize: blockSize}
+}
+
+// Add adds a new item to the tail of the unrolled linked list.
+func (l *UnrolledLinkedList) Add(item interface{}) *UnrolledLinkedList {
+	return l.AddTail(item)
+}
+
+// AddHead adds a new item to the head of the unrolled linked list.
+func (l *UnrolledLinkedList) AddHead(item interface{}) *UnrolledLinkedList {
+	if l.head == nil || l.head.start == 0 {
+		// the new node is filled from the end so that following
+		// AddHead calls can reuse it.
+		node := &unrolledLinkedListNode{
+			items: make([]interface{}, l.blockSize),
+			start: l.blockSize,
+		}
+		node.next = l.head
+		if l.head != nil {
+			l.head.previous = node
+		} else {
+			l.tail = node
+		}
+		l.head = node
+	}
+	l.head.start--
+	l.head.count++
+	l.head.items[l.head.start] = item
+	l.length++
+	return l
+}
+
+// AddTail adds a new item to the tail of the unrolled linked list.
+func (l *UnrolledLinkedList) AddTail(item interface{}) *UnrolledLinkedList {
+	if l.tail == nil || l.tail.start+l.tail.count == l.blockSize {
+		node := &unrolledLinkedListNode{
+			items: make([]interface{}, l.blockSize),
+		}
+		node.previous = l.tail
+		if l.tail != nil {
+			l.tail.next = node
+		} else {
+			l.head = node
+		}
+		l.tail = node
+	}
+	l.tail.items[l.tail.start+l.tail.count] = item
+	l.tail.count++
+	l.length++
+	return l
+}
+
+// RemoveHead removes an item from the head of the unrolled linked list.
+func (l *UnrolledLinkedList) RemoveHead() *UnrolledLinkedList {
+	if l.IsEmpty() {
+		return l
+	}
+	// clearing the slot so the removed item can be garbage collected.
+	l.head.items[l.head.start] = nil
+	l.head.start++
+	l.head.count--
+	l.length--
+	if l.head.count == 0 {
+		l.head = l.head.next
+		if l.head == nil {
+			l.tail = nil
+		} else {
+			l.head.previous = nil
+		}
+	}
+	return l
+}
+
+// RemoveTail removes an item from the tail of the unrolled linked list.
+func (l *UnrolledLinkedList) RemoveTail() *UnrolledLinkedList {
+	if l.IsEmpty() {
+		return l
+	}
+	l.tail.count--
+	l.tail.items[l.tail.start+l.tail.count] = nil
+	l.length--
+	if l.tail.count == 0 {
+		l.tail = l.tail.previous
+		if l.tail == nil {
+			l.head = nil
+		} else {
+			l.tail.next = nil
+		}
+	}
+	return l
+}
+
+// GetHead returns the item at the head of the list.
+func (l *UnrolledLinkedList) GetHead() (interface{}, error) {
+	if l.IsEmpty() {
+		return nil, errors.New("list is empty")
+	}
+	return l.head.items[l.head.start], nil
+}
+
+// GetTail returns the item at the tail of the list.
+func (l *UnrolledLinkedList) GetTail() (interface{}, error) {
+	if l.IsEmpty() {
+		return nil, errors.New("list is empty")
+	}
+	return l.tail.items[l.tail.start+l.tail.count-1], nil
+}
+
+// Clear clears all the values from the unrolled linked list.
+func (l *UnrolledLinkedList) Clear() {
+	trav := l.head
+	for trav != nil {
+		next := trav.next
+		trav.next = nil
+		trav.previous = nil
+		trav = next
+	}
+	l.head = nil
+	l.tail = nil
+	l.length = 0
+}
+
+// Iterate iterates through the unrolled linked list and executes the
+// callback function f for each iteration.
+func (l *UnrolledLinkedList) Iterate(f func(index int, item interface{})) {
+	trav := l.head
+	index := 0
+	for trav != nil {
+		next := trav.next
+		for i := trav.start; i < trav.start+trav.count; i++ {
+			f(index, trav.items[i])
+			index++
+		}
+		trav = next
+	}
+}
+
+// Size retrieves the size of the list.
+func (l *UnrolledLinkedList) Size() int {
+	return l.length
+}
+
+// IsEmpty returns true if the list is empty else false.
+func (l *UnrolledLinkedList) IsEmpty() bool {
+	return l.length == 0
+}
+
+// BlockSize returns the maximum number of items stored per node.
+func (l *UnrolledLinkedList) BlockSize() int {
+	return l.blockSize
+}
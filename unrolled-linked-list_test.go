@@ -0,0 +1,203 @@
+package datastructures
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func unrolledLinkedListItems(l *UnrolledLinkedList) []interface{} {
+	items := []interface{}{}
+	l.Iterate(func(_ int, item interface{}) {
+		items = append(items, item)
+	})
+	return items
+}
+
+func TestNewUnrolledLinkedList(t *testing.T) {
+	tests := []struct {
+		name      string
+		blockSize int
+		want      int
+	}{
+		{
+			name:      "valid block size",
+			blockSize: 8,
+			want:      8,
+		},
+		{
+			name:      "zero block size",
+			blockSize: 0,
+			want:      64,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewUnrolledLinkedList(tt.blockSize).BlockSize(); got != tt.want {
+				t.Errorf("NewUnrolledLinkedList() block size = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnrolledLinkedList_AddHeadAddTail(t *testing.T) {
+	tests := []struct {
+		name      string
+		blockSize int
+		heads     []interface{}
+		tails     []interface{}
+		want      []interface{}
+	}{
+		{
+			name:      "tail items across blocks",
+			blockSize: 2,
+			tails:     []interface{}{1, 2, 3, 4, 5},
+			want:      []interface{}{1, 2, 3, 4, 5},
+		},
+		{
+			name:      "head items across blocks",
+			blockSize: 2,
+			heads:     []interface{}{1, 2, 3, 4, 5},
+			want:      []interface{}{5, 4, 3, 2, 1},
+		},
+		{
+			name:      "head and tail items",
+			blockSize: 3,
+			heads:     []interface{}{"b", "a"},
+			tails:     []interface{}{"c", "d", "e", "f"},
+			want:      []interface{}{"a", "b", "c", "d", "e", "f"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewUnrolledLinkedList(tt.blockSize)
+			for _, item := range tt.heads {
+				l.AddHead(item)
+			}
+			for _, item := range tt.tails {
+				l.AddTail(item)
+			}
+			if got := unrolledLinkedListItems(l); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UnrolledLinkedList items = %v, want %v", got, tt.want)
+			}
+			if l.Size() != len(tt.want) {
+				t.Errorf("UnrolledLinkedList.Size() = %v, want %v", l.Size(), len(tt.want))
+			}
+			head, _ := l.GetHead()
+			if head != tt.want[0] {
+				t.Errorf("UnrolledLinkedList.GetHead() = %v, want %v", head, tt.want[0])
+			}
+			tail, _ := l.GetTail()
+			if tail != tt.want[len(tt.want)-1] {
+				t.Errorf("UnrolledLinkedList.GetTail() = %v, want %v", tail, tt.want[len(tt.want)-1])
+			}
+		})
+	}
+}
+
+func TestUnrolledLinkedList_RemoveHeadRemoveTail(t *testing.T) {
+	tests := []struct {
+		name        string
+		items       []interface{}
+		removeHeads int
+		removeTails int
+		want        []interface{}
+	}{
+		{
+			name:        "removing heads across blocks",
+			items:       []interface{}{1, 2, 3, 4, 5},
+			removeHeads: 3,
+			want:        []interface{}{4, 5},
+		},
+		{
+			name:        "removing tails across blocks",
+			items:       []interface{}{1, 2, 3, 4, 5},
+			removeTails: 3,
+			want:        []interface{}{1, 2},
+		},
+		{
+			name:        "removing every item",
+			items:       []interface{}{1, 2, 3},
+			removeHeads: 2,
+			removeTails: 2,
+			want:        []interface{}{},
+		},
+		{
+			name:        "removing from empty list",
+			removeHeads: 1,
+			removeTails: 1,
+			want:        []interface{}{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewUnrolledLinkedList(2)
+			for _, item := range tt.items {
+				l.Add(item)
+			}
+			for i := 0; i < tt.removeHeads; i++ {
+				l.RemoveHead()
+			}
+			for i := 0; i < tt.removeTails; i++ {
+				l.RemoveTail()
+			}
+			if got := unrolledLinkedListItems(l); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UnrolledLinkedList items = %v, want %v", got, tt.want)
+			}
+			if l.Size() != len(tt.want) {
+				t.Errorf("UnrolledLinkedList.Size() = %v, want %v", l.Size(), len(tt.want))
+			}
+			// the list must still be usable after the removals.
+			l.Add("new")
+			if tail, _ := l.GetTail(); tail != "new" {
+				t.Errorf("UnrolledLinkedList.GetTail() = %v, want %v", tail, "new")
+			}
+		})
+	}
+}
+
+func TestUnrolledLinkedList_GetHeadGetTail(t *testing.T) {
+	l := NewUnrolledLinkedList(4)
+	if _, err := l.GetHead(); err == nil {
+		t.Errorf("UnrolledLinkedList.GetHead() error = %v, wantErr %v", err, true)
+	}
+	if _, err := l.GetTail(); err == nil {
+		t.Errorf("UnrolledLinkedList.GetTail() error = %v, wantErr %v", err, true)
+	}
+}
+
+func TestUnrolledLinkedList_Clear(t *testing.T) {
+	l := NewUnrolledLinkedList(2)
+	l.Add(1).Add(2).Add(3)
+	l.Clear()
+	if !l.IsEmpty() {
+		t.Errorf("UnrolledLinkedList.IsEmpty() = %v, want %v", l.IsEmpty(), true)
+	}
+	l.Add(4)
+	if got := unrolledLinkedListItems(l); !reflect.DeepEqual(got, []interface{}{4}) {
+		t.Errorf("UnrolledLinkedList items = %v, want %v", got, []interface{}{4})
+	}
+}
+
+// the B/op reported by the following benchmarks is the memory allocated
+// per element added to the list.
+
+func BenchmarkDoublyLinkedList_Add(b *testing.B) {
+	b.ReportAllocs()
+	l := NewDoublyLinkedList()
+	for i := 0; i < b.N; i++ {
+		l.Add(&DoublyLinkedListNode{Data: i})
+	}
+}
+
+func BenchmarkUnrolledLinkedList_Add(b *testing.B) {
+	for _, blockSize := range []int{8, 64, 256} {
+		b.Run("block size "+strconv.Itoa(blockSize), func(b *testing.B) {
+			b.ReportAllocs()
+			l := NewUnrolledLinkedList(blockSize)
+			for i := 0; i < b.N; i++ {
+				l.Add(i)
+			}
+		})
+	}
+}